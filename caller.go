@@ -142,3 +142,88 @@ func GetCaller(depth int) Caller {
 		depth: depth,
 	}
 }
+
+// CallerFromPC returns the caller information from given program counter, e.g. from the result of runtime.Callers
+// or the PC field of slog.Record.
+//
+// Returns Caller with empty values if the program counter is zero or cannot be resolved.
+func CallerFromPC(pc uintptr) Caller {
+	if pc == 0 {
+		return &caller{}
+	}
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	return &caller{
+		pc:   pc,
+		file: frame.File,
+		line: frame.Line,
+	}
+}
+
+// FrameFilter reports whether the frame should be kept in the captured stack trace.
+type FrameFilter func(frame runtime.Frame) bool
+
+const maleoPackage = "github.com/tigorlazuardi/maleo"
+
+// DefaultFrameFilter drops frames from the Go runtime, the standard library, and maleo itself including its
+// subpackages, e.g. maleohttp, so only the frames of the application and third party libraries are kept.
+//
+// Frames from maleo's own test files are kept.
+func DefaultFrameFilter(frame runtime.Frame) bool {
+	pkg := framePackage(frame.Function)
+	if pkg == maleoPackage || strings.HasPrefix(pkg, maleoPackage+"/") {
+		return strings.HasSuffix(frame.File, "_test.go")
+	}
+	if pkg == "main" {
+		return true
+	}
+	// Standard library packages do not have a dot in the first element of their import path.
+	first, _, _ := strings.Cut(pkg, "/")
+	return strings.Contains(first, ".")
+}
+
+// framePackage returns the import path of the package of given fully qualified function name.
+//
+// e.g. "github.com/foo/bar.(*Baz).Qux" returns "github.com/foo/bar".
+func framePackage(function string) string {
+	slash := strings.LastIndex(function, "/")
+	dot := strings.Index(function[slash+1:], ".")
+	if dot < 0 {
+		return function
+	}
+	return function[:slash+1+dot]
+}
+
+// GetCallers returns the stack trace for who calls this function, with the same depth semantic as GetCaller.
+//
+// At most max frames are captured before the filter is applied. A nil filter keeps every frame.
+func GetCallers(depth, max int, filter FrameFilter) []Caller {
+	return callersFromPCs(capturePCs(depth+1, max), filter)
+}
+
+// capturePCs captures the program counters of the stack, with the same depth semantic as GetCaller.
+func capturePCs(depth, max int) []uintptr {
+	if max <= 0 {
+		return nil
+	}
+	pcs := make([]uintptr, max)
+	// +1 to account for capturePCs itself, since runtime.Callers counts itself as 0.
+	n := runtime.Callers(depth+1, pcs)
+	return pcs[:n]
+}
+
+func callersFromPCs(pcs []uintptr, filter FrameFilter) []Caller {
+	if len(pcs) == 0 {
+		return nil
+	}
+	out := make([]Caller, 0, len(pcs))
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		if filter == nil || filter(frame) {
+			out = append(out, &caller{pc: frame.PC, file: frame.File, line: frame.Line})
+		}
+		if !more {
+			return out
+		}
+	}
+}
@@ -2,6 +2,7 @@ package maleo
 
 import (
 	"bytes"
+	"runtime"
 	"strings"
 	"testing"
 )
@@ -50,3 +51,50 @@ func TestCaller(t *testing.T) {
 		t.Errorf("Expected caller marshal json to be caller_test.go:, got %s", b)
 	}
 }
+
+func TestDefaultFrameFilter(t *testing.T) {
+	tests := []struct {
+		function string
+		file     string
+		want     bool
+	}{
+		{"runtime.goexit", "/usr/local/go/src/runtime/asm_amd64.s", false},
+		{"testing.tRunner", "/usr/local/go/src/testing/testing.go", false},
+		{"net/http.HandlerFunc.ServeHTTP", "/usr/local/go/src/net/http/server.go", false},
+		{"github.com/tigorlazuardi/maleo.(*Maleo).Wrap", "/maleo/maleo.go", false},
+		{"github.com/tigorlazuardi/maleo.TestDefaultFrameFilter", "/maleo/caller_test.go", true},
+		{"github.com/tigorlazuardi/maleo/maleohttp.(*Responder).RespondError", "/maleo/maleohttp/responder.go", false},
+		{"github.com/tigorlazuardi/maleo/maleohttp.TestResponder_RespondError", "/maleo/maleohttp/responder_test.go", true},
+		{"github.com/tigorlazuardi/maleofoo.Bar", "/maleofoo/bar.go", true},
+		{"github.com/foo/bar.(*Baz).Qux", "/bar/baz.go", true},
+		{"main.main", "/app/main.go", true},
+		{"main.run.func1", "/app/main.go", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.function, func(t *testing.T) {
+			if got := DefaultFrameFilter(runtime.Frame{Function: tt.function, File: tt.file}); got != tt.want {
+				t.Errorf("DefaultFrameFilter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetCallers(t *testing.T) {
+	callers := GetCallers(1, 32, nil)
+	if len(callers) < 2 {
+		t.Fatalf("expected at least 2 frames, got %d", len(callers))
+	}
+	if callers[0].ShortName() != "maleo.TestGetCallers" {
+		t.Errorf("expected first frame to be maleo.TestGetCallers, got %s", callers[0].ShortName())
+	}
+	if callers[1].ShortName() != "testing.tRunner" {
+		t.Errorf("expected second frame to be testing.tRunner, got %s", callers[1].ShortName())
+	}
+	filtered := GetCallers(1, 32, DefaultFrameFilter)
+	if len(filtered) != 1 || filtered[0].ShortName() != "maleo.TestGetCallers" {
+		t.Errorf("expected only maleo.TestGetCallers to be kept, got %v", filtered)
+	}
+	if got := GetCallers(1, 0, nil); got != nil {
+		t.Errorf("expected nil frames when max is 0, got %v", got)
+	}
+}
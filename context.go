@@ -2,6 +2,7 @@ package maleo
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 )
 
@@ -46,3 +47,140 @@ func MaleoFromContext(ctx context.Context) *Maleo {
 	m, _ := ctx.Value(contextKeyMaleo).(*Maleo)
 	return m
 }
+
+var contextKeyFields = contextKey{name: "fields"}
+
+// ContextWithFields creates a new context with request scoped fields attached.
+//
+// The fields are merged with the fields already attached to the parent context, where the given fields take precedence.
+// Every Entry and Error that is logged or notified with the context will carry the fields.
+//
+// Example:
+//
+//	ctx = maleo.ContextWithFields(ctx, maleo.F{"user_id": user.ID, "tenant": tenant})
+func ContextWithFields(parent context.Context, fields Fields) context.Context {
+	return context.WithValue(parent, contextKeyFields, mergeFields(FieldsFromContext(parent), fields))
+}
+
+// FieldsFromContext retrieves the fields attached by ContextWithFields.
+//
+// Returns nil if there are no fields attached. The returned Fields must not be modified.
+func FieldsFromContext(ctx context.Context) Fields {
+	f, _ := ctx.Value(contextKeyFields).(Fields)
+	return f
+}
+
+// entryWithContextFields returns a copy of the entry that carries the fields attached to ctx.
+//
+// Entry implementations other than EntryNode are returned as is.
+func entryWithContextFields(ctx context.Context, entry Entry) Entry {
+	fields := FieldsFromContext(ctx)
+	if len(fields) == 0 {
+		return entry
+	}
+	if e, ok := entry.(EntryNode); ok {
+		e.contextFields = fields
+		e.fields = &cachedFields{}
+		return e
+	}
+	return entry
+}
+
+// errorWithContextFields returns the error with the fields attached to ctx, for Loggers. The error itself is not
+// modified, so the fields are carried by a contextFieldsError.
+//
+// Error implementations other than *ErrorNode are returned as is.
+func errorWithContextFields(ctx context.Context, err Error) Error {
+	fields := FieldsFromContext(ctx)
+	if len(fields) == 0 {
+		return err
+	}
+	if e, ok := err.(*ErrorNode); ok { //nolint:errorlint
+		return contextFieldsError{ErrorNode: e, fields: fields, cache: &cachedFields{}}
+	}
+	return err
+}
+
+var (
+	_ Error             = contextFieldsError{}
+	_ ContextFieldsHint = contextFieldsError{}
+	_ json.Marshaler    = contextFieldsError{}
+)
+
+// contextFieldsError is the ErrorNode given to Loggers with the request scoped fields of the Log call. It behaves as
+// the ErrorNode itself, including for errors.Is and errors.As.
+type contextFieldsError struct {
+	*ErrorNode
+	fields Fields
+	cache  *cachedFields
+}
+
+// ContextFields returns the ContextFields of the error with the request scoped fields added.
+func (e contextFieldsError) ContextFields() Fields {
+	return e.cache.get(func() Fields {
+		return e.ErrorNode.contextFieldsWith(e.fields)
+	})
+}
+
+func (e contextFieldsError) MarshalJSON() ([]byte, error) {
+	return e.ErrorNode.marshalJSON(e.ContextFields())
+}
+
+// Is reports whether target is the wrapped ErrorNode.
+func (e contextFieldsError) Is(target error) bool {
+	return target == error(e.ErrorNode) //nolint:errorlint
+}
+
+// As sets target to the wrapped ErrorNode if target is a **ErrorNode.
+func (e contextFieldsError) As(target any) bool {
+	if t, ok := target.(**ErrorNode); ok {
+		*t = e.ErrorNode
+		return true
+	}
+	return false
+}
+
+// messageWithContextFields returns msg with the fields attached to ctx added to its ContextFields.
+func messageWithContextFields(ctx context.Context, msg MessageContext) MessageContext {
+	fields := FieldsFromContext(ctx)
+	if len(fields) == 0 {
+		return msg
+	}
+	return &contextFieldsMessageContext{MessageContext: msg, fields: fields}
+}
+
+var (
+	_ MessageContext    = (*contextFieldsMessageContext)(nil)
+	_ ComponentHint     = (*contextFieldsMessageContext)(nil)
+	_ ContextFieldsHint = (*contextFieldsMessageContext)(nil)
+)
+
+// contextFieldsMessageContext carries the request scoped fields of a notified error, so the error itself is sent as
+// is.
+type contextFieldsMessageContext struct {
+	MessageContext
+	fields Fields
+	cache  cachedFields
+}
+
+func (c *contextFieldsMessageContext) Component() string {
+	if ch, ok := c.MessageContext.(ComponentHint); ok {
+		return ch.Component()
+	}
+	return ""
+}
+
+// ContextFields returns the ContextFields of the message with the request scoped fields added. The fields of the
+// message take precedence, except the ones inherited from Maleo.With by an ErrorNode.
+func (c *contextFieldsMessageContext) ContextFields() Fields {
+	if e, ok := c.Err().(*ErrorNode); ok { //nolint:errorlint
+		return c.cache.get(func() Fields {
+			return e.contextFieldsWith(c.fields)
+		})
+	}
+	var fields Fields
+	if cf, ok := c.MessageContext.(ContextFieldsHint); ok {
+		fields = cf.ContextFields()
+	}
+	return mergeFields(c.fields, fields)
+}
@@ -1,7 +1,10 @@
 package maleo
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"reflect"
 	"testing"
 	"time"
@@ -23,3 +26,82 @@ func TestDetachedContext(t *testing.T) {
 		t.Errorf("expected detached context done to be nil, got %#v", dctx.Done())
 	}
 }
+
+func TestContextWithFields(t *testing.T) {
+	ctx := context.Background()
+	if got := FieldsFromContext(ctx); got != nil {
+		t.Errorf("expected no fields, got %v", got)
+	}
+	parent := ContextWithFields(ctx, F{"user_id": 1, "tenant": "foo"})
+	child := ContextWithFields(parent, F{"tenant": "bar", "request_id": "abc"})
+	want := F{"user_id": 1, "tenant": "bar", "request_id": "abc"}
+	if got := FieldsFromContext(child); !reflect.DeepEqual(got, want) {
+		t.Errorf("FieldsFromContext() = %v, want %v", got, want)
+	}
+	if got := FieldsFromContext(parent); got["tenant"] != "foo" {
+		t.Errorf("parent fields should not be modified, got %v", got)
+	}
+
+	mal, logger := NewTestingMaleo()
+	messenger := &recordingMessenger{}
+	mal.Register(messenger)
+	notified := mal.Bail("boom").Log(child).Notify(child)
+	mal.NewEntry("entry").Log(child)
+
+	dec := json.NewDecoder(bytes.NewReader(logger.Bytes()))
+	for _, name := range []string{"error", "entry"} {
+		var out struct {
+			ContextFields F `json:"context_fields"`
+		}
+		if err := dec.Decode(&out); err != nil {
+			t.Fatalf("failed to decode %s log: %v", name, err)
+		}
+		if out.ContextFields["request_id"] != "abc" || out.ContextFields["tenant"] != "bar" {
+			t.Errorf("%s log context_fields = %v, want request_id and tenant", name, out.ContextFields)
+		}
+	}
+	if len(messenger.messages) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(messenger.messages))
+	}
+	cf, ok := messenger.messages[0].(ContextFieldsHint)
+	if !ok || cf.ContextFields()["user_id"] != 1 {
+		t.Errorf("expected notified message to carry context fields")
+	}
+	if got := messenger.messages[0].Err(); got != notified { //nolint:errorlint
+		t.Errorf("expected the notified error to be sent as is, got %p, want %p", got, notified)
+	}
+	if got := notified.(ContextFieldsHint).ContextFields(); got["user_id"] != nil { //nolint:errorlint
+		t.Errorf("expected the notified error to be left unmodified, got context fields %v", got)
+	}
+	if fields := FieldsFromContext(messenger.ctx); fields["user_id"] != 1 {
+		t.Errorf("expected messenger context to carry fields, got %v", fields)
+	}
+
+	// Logging without fields in context leaves the error as is.
+	err := mal.BailFreeze("plain")
+	if got := errorWithContextFields(ctx, err); got != err {
+		t.Errorf("expected the same error when context has no fields")
+	}
+
+	// The error given to Loggers with fields is the same error for errors.Is and errors.As.
+	logged := errorWithContextFields(child, err)
+	if !errors.Is(logged, err) {
+		t.Errorf("expected errors.Is to match the logged error")
+	}
+	var node *ErrorNode
+	if !errors.As(logged, &node) || node != err { //nolint:errorlint
+		t.Errorf("expected errors.As to return the logged error, got %p", node)
+	}
+}
+
+type recordingMessenger struct {
+	ctx      context.Context
+	messages []MessageContext
+}
+
+func (r *recordingMessenger) Name() string               { return "recording" }
+func (r *recordingMessenger) Wait(context.Context) error { return nil }
+func (r *recordingMessenger) SendMessage(ctx context.Context, msg MessageContext) {
+	r.ctx = ctx
+	r.messages = append(r.messages, msg)
+}
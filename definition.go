@@ -0,0 +1,227 @@
+package maleo
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+/*
+Definition is a predefined error of an error catalog, with a code, HTTP code, level, message template, key and
+whether the failed operation can be retried.
+
+Definitions are created with Define, usually as package level variables, and are registered in DefaultDefinitions
+so Messengers and maleohttp can look them up by code.
+
+	var ErrUserNotFound = maleo.Define(40401, "user.not_found", "user %s not found",
+		maleo.Option.Definition().HTTPCode(404).Level(maleo.WarnLevel),
+	)
+
+	func (r *Repo) Get(ctx context.Context, id string) (*User, error) {
+		...
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUserNotFound.Wrap(err, id).Freeze()
+		}
+	}
+
+	errors.Is(err, ErrUserNotFound) // true
+
+Definition implements error, so it can be used as the target of errors.Is.
+*/
+type Definition struct {
+	code      int
+	httpCode  int
+	level     Level
+	message   string
+	key       string
+	retryable bool
+}
+
+// Define creates a Definition and registers it in DefaultDefinitions, or in the registry set by the options.
+//
+// message is a template fed into fmt.Sprintf with the args given to New and Wrap.
+//
+// Panics if another Definition with the same code is already registered, because the codes are used to look up the
+// Definitions.
+func Define(code int, key, message string, opts ...DefinitionOption) *Definition {
+	params := &DefinitionParameters{Level: ErrorLevel, Registry: DefaultDefinitions}
+	for _, opt := range opts {
+		opt.Apply(params)
+	}
+	def := &Definition{
+		code:      code,
+		httpCode:  params.HTTPCode,
+		level:     params.Level,
+		message:   message,
+		key:       key,
+		retryable: params.Retryable,
+	}
+	if params.Registry != nil {
+		if err := params.Registry.Register(def); err != nil {
+			panic(err)
+		}
+	}
+	return def
+}
+
+// Code returns the code of the Definition.
+func (d *Definition) Code() int {
+	return d.code
+}
+
+// HTTPCode returns the HTTP code of the Definition. If not set, the HTTP code follows the code the same way
+// Error.HTTPCode does.
+func (d *Definition) HTTPCode() int {
+	if d.httpCode != 0 {
+		return d.httpCode
+	}
+	return httpCodeFromCode(d.code)
+}
+
+// Level returns the level of the Definition.
+func (d *Definition) Level() Level {
+	return d.level
+}
+
+// Message returns the message template of the Definition.
+func (d *Definition) Message() string {
+	return d.message
+}
+
+// Key returns the key of the Definition.
+func (d *Definition) Key() string {
+	return d.key
+}
+
+// Retryable returns true if the operation that failed with this Definition can be retried.
+func (d *Definition) Retryable() bool {
+	return d.retryable
+}
+
+// Error implements error, so the Definition can be used as the target of errors.Is.
+func (d *Definition) Error() string {
+	return d.key
+}
+
+func (d *Definition) format(args []any) string {
+	if len(args) > 0 {
+		return fmt.Sprintf(d.message, args...)
+	}
+	return d.message
+}
+
+// New creates an ErrorBuilder from the Definition, using the Global Maleo instance. args are fed into the message
+// template.
+//
+// Like maleo.Bail, when .Log(ctx) or .Notify(ctx) is called and the context is attached with a Maleo instance, that
+// instance is used instead.
+func (d *Definition) New(args ...any) ErrorBuilder {
+	msg := d.format(args)
+	return d.apply(Global().Wrap(&definitionError{def: d, message: msg}), msg, GetCaller(2))
+}
+
+// Wrap wraps err with the Definition, using the Global Maleo instance. args are fed into the message template.
+//
+// Like maleo.Wrap, when .Log(ctx) or .Notify(ctx) is called and the context is attached with a Maleo instance, that
+// instance is used instead.
+func (d *Definition) Wrap(err error, args ...any) ErrorBuilder {
+	if err == nil {
+		err = ErrNil
+	}
+	msg := d.format(args)
+	return d.apply(Global().Wrap(&definitionError{def: d, message: msg, err: err}), msg, GetCaller(2))
+}
+
+func (d *Definition) apply(builder ErrorBuilder, msg string, caller Caller) ErrorBuilder {
+	return builder.Code(d.code).HTTPCode(d.HTTPCode()).Level(d.level).Key(d.key).Message(msg).PublicMessage(msg).Caller(caller)
+}
+
+// definitionError marks the error chain with the Definition, so errors.Is and FindDefinition find it.
+type definitionError struct {
+	def     *Definition
+	message string
+	err     error
+}
+
+func (e *definitionError) Error() string {
+	if e.err != nil {
+		return e.err.Error()
+	}
+	return e.message
+}
+
+func (e *definitionError) Unwrap() error {
+	return e.err
+}
+
+func (e *definitionError) Is(target error) bool {
+	return target == e.def //nolint:errorlint
+}
+
+// FindDefinition returns the Definition the error was created from. Returns false if the error chain has no
+// Definition.
+func FindDefinition(err error) (*Definition, bool) {
+	var de *definitionError
+	if errors.As(err, &de) {
+		return de.def, true
+	}
+	return nil, false
+}
+
+// IsRetryable returns true if the error was created from a retryable Definition.
+func IsRetryable(err error) bool {
+	def, ok := FindDefinition(err)
+	return ok && def.Retryable()
+}
+
+// DefaultDefinitions is the registry that Define registers the Definitions to by default.
+var DefaultDefinitions = NewDefinitionRegistry()
+
+// LookupDefinition returns the Definition with the given code from DefaultDefinitions.
+func LookupDefinition(code int) (*Definition, bool) {
+	return DefaultDefinitions.Lookup(code)
+}
+
+// DefinitionRegistry lists the Definitions by code. It is safe for concurrent use.
+type DefinitionRegistry struct {
+	mu     sync.RWMutex
+	byCode map[int]*Definition
+}
+
+// NewDefinitionRegistry returns an empty DefinitionRegistry.
+func NewDefinitionRegistry() *DefinitionRegistry {
+	return &DefinitionRegistry{byCode: map[int]*Definition{}}
+}
+
+// Register adds the Definition to the registry. Returns an error if another Definition with the same code is already
+// registered.
+func (r *DefinitionRegistry) Register(def *Definition) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, ok := r.byCode[def.code]; ok && existing != def {
+		return fmt.Errorf("maleo: definition %q has the same code %d as definition %q", def.key, def.code, existing.key)
+	}
+	r.byCode[def.code] = def
+	return nil
+}
+
+// Lookup returns the Definition with the given code.
+func (r *DefinitionRegistry) Lookup(code int) (*Definition, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	def, ok := r.byCode[code]
+	return def, ok
+}
+
+// All returns the registered Definitions, sorted by code.
+func (r *DefinitionRegistry) All() []*Definition {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]*Definition, 0, len(r.byCode))
+	for _, def := range r.byCode {
+		out = append(out, def)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].code < out[j].code })
+	return out
+}
@@ -0,0 +1,59 @@
+package maleo
+
+type DefinitionParameters struct {
+	// HTTPCode is the HTTP code of the Definition. If 0, the HTTP code follows the code.
+	HTTPCode int
+	// Level is the level of the errors created from the Definition. Defaults to ErrorLevel.
+	Level Level
+	// Retryable marks the failed operation as retryable.
+	Retryable bool
+	// Registry is where the Definition is registered. Defaults to DefaultDefinitions. Set to nil to not register.
+	Registry *DefinitionRegistry
+}
+
+type DefinitionOption interface {
+	Apply(*DefinitionParameters)
+}
+
+type (
+	DefinitionOptionBuilder []DefinitionOption
+	DefinitionOptionFunc    func(*DefinitionParameters)
+)
+
+func (d DefinitionOptionFunc) Apply(parameters *DefinitionParameters) {
+	d(parameters)
+}
+
+func (d DefinitionOptionBuilder) Apply(parameters *DefinitionParameters) {
+	for _, opt := range d {
+		opt.Apply(parameters)
+	}
+}
+
+// HTTPCode sets the HTTP code of the Definition.
+func (d DefinitionOptionBuilder) HTTPCode(code int) DefinitionOptionBuilder {
+	return append(d, DefinitionOptionFunc(func(p *DefinitionParameters) {
+		p.HTTPCode = code
+	}))
+}
+
+// Level sets the level of the errors created from the Definition.
+func (d DefinitionOptionBuilder) Level(lvl Level) DefinitionOptionBuilder {
+	return append(d, DefinitionOptionFunc(func(p *DefinitionParameters) {
+		p.Level = lvl
+	}))
+}
+
+// Retryable marks the failed operation as retryable.
+func (d DefinitionOptionBuilder) Retryable(retryable bool) DefinitionOptionBuilder {
+	return append(d, DefinitionOptionFunc(func(p *DefinitionParameters) {
+		p.Retryable = retryable
+	}))
+}
+
+// Registry sets the registry the Definition is registered to. Set to nil to not register the Definition.
+func (d DefinitionOptionBuilder) Registry(registry *DefinitionRegistry) DefinitionOptionBuilder {
+	return append(d, DefinitionOptionFunc(func(p *DefinitionParameters) {
+		p.Registry = registry
+	}))
+}
@@ -0,0 +1,75 @@
+package maleo
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+)
+
+var errTestRateLimited = Define(42901, "test.rate_limited", "rate limited for %s",
+	Option.Definition().HTTPCode(429).Level(WarnLevel).Retryable(true),
+)
+
+func TestDefinition(t *testing.T) {
+	err := errTestRateLimited.New("user-1").Freeze()
+	if err.Message() != "rate limited for user-1" || err.Code() != 42901 || err.Key() != "test.rate_limited" {
+		t.Errorf("message = %q, code = %d, key = %q", err.Message(), err.Code(), err.Key())
+	}
+	if err.HTTPCode() != 429 || err.Level() != WarnLevel {
+		t.Errorf("http code = %d, level = %v, want 429 and warn", err.HTTPCode(), err.Level())
+	}
+	if !strings.HasSuffix(err.Caller().File(), "definition_test.go") {
+		t.Errorf("caller = %v, want definition_test.go", err.Caller())
+	}
+	if !errors.Is(err, errTestRateLimited) || !IsRetryable(err) {
+		t.Error("error created by New must match the Definition")
+	}
+
+	wrapped := errTestRateLimited.Wrap(sql.ErrNoRows, "user-2").Freeze()
+	if !errors.Is(wrapped, errTestRateLimited) || !errors.Is(wrapped, sql.ErrNoRows) {
+		t.Error("error created by Wrap must match both the Definition and the wrapped error")
+	}
+	outer := Wrap(wrapped, "outer").Freeze()
+	if def, ok := FindDefinition(outer); !ok || def != errTestRateLimited {
+		t.Errorf("FindDefinition() = %v, %v", def, ok)
+	}
+	if _, ok := FindDefinition(errors.New("plain")); ok || IsRetryable(errors.New("plain")) {
+		t.Error("plain errors must not have a Definition")
+	}
+	if def, ok := LookupDefinition(42901); !ok || def != errTestRateLimited {
+		t.Errorf("LookupDefinition() = %v, %v", def, ok)
+	}
+	if code := Bail("by code").Code(42901).Freeze().HTTPCode(); code != 500 {
+		t.Errorf("errors not built from a Definition must not use its HTTP code, got %d", code)
+	}
+	if code := Wrap(errTestRateLimited.New().Freeze()).Code(42901).Freeze().HTTPCode(); code != 429 {
+		t.Errorf("errors wrapping a Definition with the same code must use its HTTP code, got %d", code)
+	}
+}
+
+func TestDefinitionRegistry(t *testing.T) {
+	registry := NewDefinitionRegistry()
+	opts := Option.Definition().Registry(registry)
+	notFound := Define(404, "not_found", "not found", opts)
+	conflict := Define(409, "conflict", "conflict", opts)
+	if notFound.HTTPCode() != 404 || notFound.Level() != ErrorLevel || notFound.Retryable() {
+		t.Errorf("defaults: http code = %d, level = %v, retryable = %v", notFound.HTTPCode(), notFound.Level(), notFound.Retryable())
+	}
+	if all := registry.All(); len(all) != 2 || all[0] != notFound || all[1] != conflict {
+		t.Errorf("All() = %v, want definitions sorted by code", all)
+	}
+	if err := registry.Register(notFound); err != nil {
+		t.Errorf("registering the same Definition again must be a no-op, got %v", err)
+	}
+	err := registry.Register(Define(404, "missing", "missing", Option.Definition().Registry(nil)))
+	if err == nil || !strings.Contains(err.Error(), `"missing" has the same code 404 as definition "not_found"`) {
+		t.Errorf("Register() error = %v", err)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("Define() with a duplicate code must panic")
+		}
+	}()
+	Define(409, "duplicate", "duplicate", opts)
+}
@@ -0,0 +1,270 @@
+package maleo
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DigestGroup summarises the messages of one group collected by the Digest middleware in one window.
+type DigestGroup struct {
+	// Group is the value returned by the Group function of the DigestParameters.
+	Group string `json:"group"`
+	// Message is the message text of the first message in the group.
+	Message string `json:"message"`
+	// Level is the highest level in the group.
+	Level Level `json:"level"`
+	// Count is the number of messages in the group.
+	Count int `json:"count"`
+	// FirstSeen is the time of the first message in the group.
+	FirstSeen time.Time `json:"first_seen"`
+	// LastSeen is the time of the last message in the group.
+	LastSeen time.Time `json:"last_seen"`
+	// Services are the distinct services that sent the messages, in order of appearance.
+	Services []Service `json:"services"`
+	// Sample is the first message in the group.
+	Sample MessageContext `json:"-"`
+}
+
+// Digest summarises all the messages collected by the Digest middleware in one window.
+type Digest struct {
+	// Count is the number of messages in the window.
+	Count int `json:"count"`
+	// FirstSeen is the time of the first message in the window.
+	FirstSeen time.Time `json:"first_seen"`
+	// LastSeen is the time of the last message in the window.
+	LastSeen time.Time `json:"last_seen"`
+	// Services are the distinct services that sent the messages, in order of appearance.
+	Services []Service `json:"services"`
+	// Groups are the groups of the messages, in order of appearance.
+	Groups []DigestGroup `json:"groups"`
+}
+
+// DigestKeyPrefix prefixes the keys of the digests sent by the Digest middleware.
+const DigestKeyPrefix = "digest:"
+
+// DigestHint is implemented by the MessageContext sent by the Digest middleware.
+type DigestHint interface {
+	// Digest returns the summary of the messages in the digest.
+	Digest() Digest
+}
+
+/*
+Digest collects the messages over a window, and sends one digest of all of them to the wrapped Messenger when the
+window ends, instead of one message each. Useful when a dependency goes down and many different errors are reported at
+once.
+
+The messages are grouped, and the digest lists every group with its own count. The digest is a MessageContext built
+from the first message of the window, the sample. The message text notes the counts, the level is the highest level in
+the window, and the Context has the summary under the "digest" key. The summary is also available via DigestHint. The
+key is the key of the sample prefixed with DigestKeyPrefix, so the cooldown of the sample does not hold the digest back.
+A window with a single message sends the original message.
+
+Messages with ForceSend, or with levels enabled by the bypass level (FatalLevel by default), are sent immediately.
+Wait sends the collected messages right away before waiting for the wrapped Messenger.
+
+Example:
+
+	m.Register(maleo.Chain(discord, maleo.Middleware.Digest(
+		maleo.Option.Digest().Window(time.Minute).GroupByCode(),
+	)))
+*/
+func (middleware) Digest(opts ...DigestOption) MessengerMiddleware {
+	params := &DigestParameters{
+		Window: time.Minute,
+		Group:  DigestGroupKey,
+		Bypass: FatalLevel,
+	}
+	for _, opt := range opts {
+		opt.Apply(params)
+	}
+	return func(next Messenger) Messenger {
+		return &digestMessenger{Messenger: next, params: params}
+	}
+}
+
+type digestMessenger struct {
+	Messenger
+	params *DigestParameters
+
+	mu     sync.Mutex
+	timer  *time.Timer
+	window *digestWindow
+}
+
+type digestWindow struct {
+	ctx    context.Context
+	sample MessageContext
+	level  Level
+	digest Digest
+	index  map[string]int
+}
+
+func (d *digestMessenger) SendMessage(ctx context.Context, msg MessageContext) {
+	if msg.ForceSend() || (d.params.Bypass != nil && d.params.Bypass.Enabled(msg.Level())) {
+		d.Messenger.SendMessage(ctx, msg)
+		return
+	}
+	key := d.params.Group(msg)
+	now := msg.Time()
+	if now.IsZero() {
+		now = time.Now()
+	}
+	service := msg.Service()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	w := d.window
+	if w == nil {
+		w = &digestWindow{
+			ctx:    DetachedContext(ctx),
+			sample: msg,
+			level:  msg.Level(),
+			digest: Digest{FirstSeen: now},
+			index:  map[string]int{},
+		}
+		d.window = w
+	}
+	i, ok := w.index[key]
+	if !ok {
+		i = len(w.digest.Groups)
+		w.index[key] = i
+		w.digest.Groups = append(w.digest.Groups, DigestGroup{
+			Group:     key,
+			Message:   msg.Message(),
+			Level:     msg.Level(),
+			FirstSeen: now,
+			Sample:    msg,
+		})
+	}
+	group := &w.digest.Groups[i]
+	group.Count++
+	group.LastSeen = now
+	w.digest.Count++
+	w.digest.LastSeen = now
+	if lvl := msg.Level(); lvl > group.Level {
+		group.Level = lvl
+	}
+	if lvl := msg.Level(); lvl > w.level {
+		w.level = lvl
+	}
+	if !containsService(group.Services, service) {
+		group.Services = append(group.Services, service)
+	}
+	if !containsService(w.digest.Services, service) {
+		w.digest.Services = append(w.digest.Services, service)
+	}
+	if d.timer == nil {
+		d.timer = time.AfterFunc(d.params.Window, d.flush)
+	}
+}
+
+func containsService(services []Service, service Service) bool {
+	for _, s := range services {
+		if s == service {
+			return true
+		}
+	}
+	return false
+}
+
+// flush sends the digest of the window to the wrapped Messenger and starts a new window.
+func (d *digestMessenger) flush() {
+	d.mu.Lock()
+	w := d.window
+	d.window = nil
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+	d.mu.Unlock()
+	if w == nil {
+		return
+	}
+	if w.digest.Count == 1 {
+		d.Messenger.SendMessage(w.ctx, w.sample)
+		return
+	}
+	d.Messenger.SendMessage(w.ctx, &digestMessageContext{
+		MessageContext: w.sample,
+		level:          w.level,
+		digest:         w.digest,
+	})
+}
+
+func (d *digestMessenger) Wait(ctx context.Context) error {
+	d.flush()
+	return d.Messenger.Wait(ctx)
+}
+
+func (d *digestMessenger) Unwrap() Messenger {
+	return d.Messenger
+}
+
+var (
+	_ MessageContext    = (*digestMessageContext)(nil)
+	_ DigestHint        = (*digestMessageContext)(nil)
+	_ ComponentHint     = (*digestMessageContext)(nil)
+	_ ContextFieldsHint = (*digestMessageContext)(nil)
+)
+
+type digestMessageContext struct {
+	MessageContext
+	level  Level
+	digest Digest
+}
+
+func (d *digestMessageContext) Message() string {
+	first := d.digest.Groups[0]
+	msg := fmt.Sprintf("%s (%d times)", first.Message, first.Count)
+	switch others := len(d.digest.Groups) - 1; {
+	case others == 1:
+		msg += fmt.Sprintf(" and %d more in 1 other group", d.digest.Count-first.Count)
+	case others > 1:
+		msg += fmt.Sprintf(" and %d more in %d other groups", d.digest.Count-first.Count, others)
+	}
+	return msg
+}
+
+func (d *digestMessageContext) Level() Level {
+	return d.level
+}
+
+// Key returns the key of the sample, or its fingerprint if the sample has no key, prefixed with DigestKeyPrefix. So
+// Messengers that group messages by key, e.g. for cooldowns, do not take the digest for a repeat of the sample.
+func (d *digestMessageContext) Key() string {
+	key := d.MessageContext.Key()
+	if m := d.MessageContext.Maleo(); key == "" && m != nil {
+		key = m.MessageKey(d.MessageContext)
+	}
+	return DigestKeyPrefix + key
+}
+
+func (d *digestMessageContext) Context() []any {
+	data := d.MessageContext.Context()
+	out := make([]any, 0, len(data)+1)
+	out = append(out, data...)
+	return append(out, F{"digest": d.digest})
+}
+
+func (d *digestMessageContext) ForceSend() bool {
+	return false
+}
+
+func (d *digestMessageContext) Digest() Digest {
+	return d.digest
+}
+
+func (d *digestMessageContext) Component() string {
+	if ch, ok := d.MessageContext.(ComponentHint); ok {
+		return ch.Component()
+	}
+	return ""
+}
+
+func (d *digestMessageContext) ContextFields() Fields {
+	if cf, ok := d.MessageContext.(ContextFieldsHint); ok {
+		return cf.ContextFields()
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package maleo
+
+import (
+	"strconv"
+	"time"
+)
+
+type DigestParameters struct {
+	// Window is how long messages are collected before the digest is sent. Defaults to 1 minute.
+	Window time.Duration
+	// Group returns the group of the message. Messages with the same group in a window are counted together in the digest.
+	// Defaults to DigestGroupKey.
+	Group func(msg MessageContext) string
+	// Bypass sends the messages with enabled levels to the wrapped Messenger immediately, without waiting for the window.
+	// Defaults to FatalLevel. Messages with ForceSend always bypass the window.
+	Bypass LevelEnabler
+}
+
+type DigestOption interface {
+	Apply(*DigestParameters)
+}
+
+type (
+	DigestOptionBuilder []DigestOption
+	DigestOptionFunc    func(*DigestParameters)
+)
+
+func (d DigestOptionFunc) Apply(parameters *DigestParameters) {
+	d(parameters)
+}
+
+func (d DigestOptionBuilder) Apply(parameters *DigestParameters) {
+	for _, opt := range d {
+		opt.Apply(parameters)
+	}
+}
+
+// Window sets how long messages are collected before the digest is sent.
+func (d DigestOptionBuilder) Window(window time.Duration) DigestOptionBuilder {
+	return append(d, DigestOptionFunc(func(p *DigestParameters) {
+		p.Window = window
+	}))
+}
+
+// GroupBy sets the function that returns the group of the message.
+func (d DigestOptionBuilder) GroupBy(group func(msg MessageContext) string) DigestOptionBuilder {
+	return append(d, DigestOptionFunc(func(p *DigestParameters) {
+		p.Group = group
+	}))
+}
+
+// GroupByKey groups the messages by their key, or by their fingerprint if the key is empty.
+func (d DigestOptionBuilder) GroupByKey() DigestOptionBuilder {
+	return d.GroupBy(DigestGroupKey)
+}
+
+// GroupByCaller groups the messages by their caller.
+func (d DigestOptionBuilder) GroupByCaller() DigestOptionBuilder {
+	return d.GroupBy(DigestGroupCaller)
+}
+
+// GroupByCode groups the messages by their code, e.g. to collect every 503 error while a dependency is down.
+func (d DigestOptionBuilder) GroupByCode() DigestOptionBuilder {
+	return d.GroupBy(DigestGroupCode)
+}
+
+// Bypass sends the messages with levels enabled by lvl to the wrapped Messenger immediately.
+// Set to nil to collect messages of every level.
+func (d DigestOptionBuilder) Bypass(lvl LevelEnabler) DigestOptionBuilder {
+	return append(d, DigestOptionFunc(func(p *DigestParameters) {
+		p.Bypass = lvl
+	}))
+}
+
+// DigestGroupKey returns the key of the message, or the fingerprint of the message if the key is empty.
+func DigestGroupKey(msg MessageContext) string {
+	return msg.Maleo().MessageKey(msg)
+}
+
+// DigestGroupCaller returns the caller of the message.
+func DigestGroupCaller(msg MessageContext) string {
+	if c := msg.Caller(); c != nil {
+		return c.String()
+	}
+	return ""
+}
+
+// DigestGroupCode returns the code of the message.
+func DigestGroupCode(msg MessageContext) string {
+	return strconv.Itoa(msg.Code())
+}
@@ -0,0 +1,143 @@
+package maleo
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+type channelMessenger struct {
+	messages chan MessageContext
+}
+
+func (c channelMessenger) Name() string               { return "channel" }
+func (c channelMessenger) Wait(context.Context) error { return nil }
+func (c channelMessenger) SendMessage(_ context.Context, msg MessageContext) {
+	c.messages <- msg
+}
+
+func TestMiddleware_Digest(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("sends one digest of all groups on Wait", func(t *testing.T) {
+		inner := &recordingMessenger{}
+		api := New(Service{Name: "api"})
+		worker := New(Service{Name: "worker"})
+		messenger := Chain(inner, Middleware.Digest(Option.Digest().Window(time.Hour).GroupByCode()))
+		api.Register(messenger)
+		worker.Register(messenger)
+
+		_ = api.Bail("redis down").Code(503).Key("cache.get").Level(WarnLevel).Notify(ctx)
+		_ = api.Bail("redis down").Code(503).Key("cache.set").Notify(ctx)
+		_ = worker.Bail("redis down").Code(503).Key("queue.pop").Level(WarnLevel).Notify(ctx)
+		_ = api.Bail("bad input").Code(400).Level(WarnLevel).Notify(ctx)
+		if len(inner.messages) != 0 {
+			t.Fatalf("expected messages to be held until the window ends, got %d", len(inner.messages))
+		}
+		if err := api.Wait(ctx); err != nil {
+			t.Fatalf("Wait() error = %v", err)
+		}
+		if len(inner.messages) != 1 {
+			t.Fatalf("expected 1 digest after Wait, got %d", len(inner.messages))
+		}
+
+		digest := inner.messages[0]
+		if got, want := digest.Message(), "redis down (3 times) and 1 more in 1 other group"; got != want {
+			t.Errorf("Message() = %q, want %q", got, want)
+		}
+		if digest.Key() != DigestKeyPrefix+"cache.get" || digest.Err() == nil {
+			t.Errorf("expected the first message as the sample, got key %q and error %v", digest.Key(), digest.Err())
+		}
+		if digest.Level() != ErrorLevel {
+			t.Errorf("Level() = %v, want the highest level of the window", digest.Level())
+		}
+		dh, ok := digest.(DigestHint)
+		if !ok {
+			t.Fatal("digest does not implement DigestHint")
+		}
+		summary := dh.Digest()
+		if summary.Count != 4 || summary.LastSeen.Before(summary.FirstSeen) {
+			t.Errorf("Digest() = %+v", summary)
+		}
+		if len(summary.Services) != 2 || summary.Services[0].Name != "api" || summary.Services[1].Name != "worker" {
+			t.Errorf("Digest().Services = %+v, want api and worker", summary.Services)
+		}
+		if len(summary.Groups) != 2 {
+			t.Fatalf("Digest().Groups = %+v, want 2 groups", summary.Groups)
+		}
+		redis, input := summary.Groups[0], summary.Groups[1]
+		if redis.Group != "503" || redis.Count != 3 || redis.Level != ErrorLevel || len(redis.Services) != 2 {
+			t.Errorf("first group = %+v", redis)
+		}
+		if input.Group != "400" || input.Count != 1 || input.Message != "bad input" || input.Sample == nil {
+			t.Errorf("second group = %+v", input)
+		}
+		data := digest.Context()
+		if f, ok := data[len(data)-1].(F); !ok || f["digest"] == nil {
+			t.Errorf("expected the summary as the last context, got %v", data)
+		}
+	})
+
+	t.Run("single message", func(t *testing.T) {
+		inner := &recordingMessenger{}
+		m := New(Service{Name: "api"})
+		m.Register(Chain(inner, Middleware.Digest(Option.Digest().Window(time.Hour))))
+		_ = m.Bail("bad input").Notify(ctx)
+		_ = m.Wait(ctx)
+		if len(inner.messages) != 1 {
+			t.Fatalf("expected 1 message, got %d", len(inner.messages))
+		}
+		if _, ok := inner.messages[0].(DigestHint); ok || inner.messages[0].Message() != "bad input" {
+			t.Errorf("expected a single message to be sent as is, got %q", inner.messages[0].Message())
+		}
+	})
+
+	t.Run("fingerprint key", func(t *testing.T) {
+		inner := &recordingMessenger{}
+		m := New(Service{Name: "api"})
+		m.Register(Chain(inner, Middleware.Digest(Option.Digest().Window(time.Hour))))
+		_ = m.Bail("timeout").Notify(ctx)
+		_ = m.Bail("timeout").Notify(ctx)
+		_ = m.Wait(ctx)
+		if len(inner.messages) != 1 {
+			t.Fatalf("expected 1 digest, got %d", len(inner.messages))
+		}
+		digest, ok := inner.messages[0].(*digestMessageContext)
+		if !ok {
+			t.Fatalf("expected a digest, got %T", inner.messages[0])
+		}
+		if got, want := digest.Key(), DigestKeyPrefix+m.Fingerprint(digest.MessageContext); got != want {
+			t.Errorf("Key() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("bypass", func(t *testing.T) {
+		inner := &recordingMessenger{}
+		m := New(Service{Name: "api"})
+		m.Register(Chain(inner, Middleware.Digest(Option.Digest().Window(time.Hour))))
+		_ = m.Bail("fatal").Level(FatalLevel).Notify(ctx)
+		_ = m.Bail("forced").Notify(ctx, Option.Message().ForceSend(true))
+		_ = m.Bail("held").Notify(ctx)
+		if len(inner.messages) != 2 {
+			t.Errorf("expected fatal and forced messages to bypass the window, got %d", len(inner.messages))
+		}
+	})
+
+	t.Run("window", func(t *testing.T) {
+		inner := channelMessenger{messages: make(chan MessageContext, 1)}
+		m := New(Service{Name: "api"})
+		m.Register(Chain(inner, Middleware.Digest(Option.Digest().Window(10*time.Millisecond))))
+		for i := 0; i < 5; i++ {
+			_ = m.Bail("timeout").Key("upstream").Notify(ctx)
+		}
+		select {
+		case msg := <-inner.messages:
+			if !strings.HasSuffix(msg.Message(), "(5 times)") {
+				t.Errorf("Message() = %q, want the count of 5", msg.Message())
+			}
+		case <-time.After(time.Second):
+			t.Fatal("expected the digest to be sent when the window ends")
+		}
+	})
+}
@@ -1,5 +1,10 @@
 package maleo
 
+// Engine constructs the Entries, Errors and MessageContexts of Maleo.
+//
+// An Engine may also implement Fingerprinter, HTTPCodeMapper, Redactor, Truncator and Hook. Maleo checks for them
+// when it needs them, and uses the defaults of NewEngine for Fingerprinter and HTTPCodeMapper, and no-ops for the
+// rest, when the Engine does not implement them. The Engine returned by NewEngine implements all of them.
 type Engine interface {
 	ErrorConstructor
 	EntryConstructor
@@ -13,6 +18,11 @@ func NewEngine(opts ...EngineOption) Engine {
 		EntryConstructor:               EntryConstructorFunc(defaultEntryConstructor),
 		EntryMessageContextConstructor: MessageContextConstructorFunc(defaultMessageContextConstructor),
 		ErrorMessageContextConstructor: ErrorMessageConstructorFunc(defaultErrorMessageContextConstructor),
+		Fingerprinter:                  FingerprinterFunc(defaultFingerprint),
+		HTTPCodeMapper:                 HTTPCodeMapperFunc(defaultHTTPCodeMapper),
+		Redactor:                       NoopRedactor{},
+		Truncator:                      NewTruncator(),
+		Hook:                           NoopHook{},
 	}
 	for _, opt := range opts {
 		opt.apply(def)
@@ -25,4 +35,57 @@ type engine struct {
 	EntryConstructor
 	EntryMessageContextConstructor
 	ErrorMessageContextConstructor
+	Fingerprinter
+	HTTPCodeMapper
+	Redactor
+	Truncator
+	Hook
+}
+
+// fingerprinter returns the Fingerprinter of the engine, or the default one if the engine does not implement it.
+func (m *Maleo) fingerprinter() Fingerprinter {
+	if f, ok := m.engine.(Fingerprinter); ok {
+		return f
+	}
+	return FingerprinterFunc(defaultFingerprint)
+}
+
+// httpCodeMapper returns the HTTPCodeMapper of the engine, or the default one if the engine does not implement it.
+func (m *Maleo) httpCodeMapper() HTTPCodeMapper {
+	if m != nil {
+		if mapper, ok := m.engine.(HTTPCodeMapper); ok {
+			return mapper
+		}
+	}
+	return HTTPCodeMapperFunc(defaultHTTPCodeMapper)
+}
+
+// redactor returns the Redactor of the engine, or NoopRedactor if the engine does not implement it.
+func (m *Maleo) redactor() Redactor {
+	switch e := m.engine.(type) {
+	case *engine:
+		return e.Redactor
+	case Redactor:
+		return e
+	}
+	return NoopRedactor{}
+}
+
+// truncator returns the Truncator of the engine, or NoopTruncator if the engine does not implement it.
+func (m *Maleo) truncator() Truncator {
+	switch e := m.engine.(type) {
+	case *engine:
+		return e.Truncator
+	case Truncator:
+		return e
+	}
+	return NoopTruncator{}
+}
+
+// hook returns the Hook of the engine, or NoopHook if the engine does not implement it.
+func (m *Maleo) hook() Hook {
+	if h, ok := m.engine.(Hook); ok {
+		return h
+	}
+	return NoopHook{}
 }
@@ -45,3 +45,57 @@ func (e EngineOptionBuilder) ErrorMessageContextConstructor(mc ErrorMessageConte
 		m.ErrorMessageContextConstructor = mc
 	}))
 }
+
+// Fingerprinter sets the Fingerprinter that builds the keys of messages without Key.
+func (e EngineOptionBuilder) Fingerprinter(f Fingerprinter) EngineOptionBuilder {
+	return append(e, EngineOptionFunc(func(m *engine) {
+		m.Fingerprinter = f
+	}))
+}
+
+// HTTPCodeMapper sets the HTTPCodeMapper that maps business codes to HTTP status codes.
+//
+// Example:
+//
+//	maleo.NewEngine(maleo.Option.Engine().HTTPCodeMapper(maleo.HTTPCodeMap{40012: http.StatusBadRequest}))
+func (e EngineOptionBuilder) HTTPCodeMapper(mapper HTTPCodeMapper) EngineOptionBuilder {
+	return append(e, EngineOptionFunc(func(m *engine) {
+		m.HTTPCodeMapper = mapper
+	}))
+}
+
+// Redactor sets the Redactor that masks sensitive data in the context values of Entries and Errors, before they are
+// logged or notified. See NewRedactor.
+func (e EngineOptionBuilder) Redactor(r Redactor) EngineOptionBuilder {
+	return append(e, EngineOptionFunc(func(m *engine) {
+		m.Redactor = r
+	}))
+}
+
+// Truncator sets the Truncator that limits the size of the context values of Entries and Errors. Defaults to
+// NewTruncator() with the default limits. Use NoopTruncator to disable the limits.
+func (e EngineOptionBuilder) Truncator(t Truncator) EngineOptionBuilder {
+	return append(e, EngineOptionFunc(func(m *engine) {
+		m.Truncator = t
+	}))
+}
+
+// Hooks sets the Hooks that run when Entries, Errors and MessageContexts are constructed, in order. See Enricher
+// for built-in Hooks.
+func (e EngineOptionBuilder) Hooks(hooks ...Hook) EngineOptionBuilder {
+	return append(e, EngineOptionFunc(func(m *engine) {
+		m.Hook = Hooks(hooks)
+	}))
+}
+
+// ErrorClassifiers classifies the errors created by the ErrorConstructor set so far with the classifiers.
+// See ClassifyingErrorConstructor.
+//
+// Example:
+//
+//	maleo.NewEngine(maleo.Option.Engine().ErrorClassifiers(maleo.StdlibClassifiers(), myClassifier))
+func (e EngineOptionBuilder) ErrorClassifiers(classifiers ...ErrorClassifier) EngineOptionBuilder {
+	return append(e, EngineOptionFunc(func(m *engine) {
+		m.ErrorConstructor = ClassifyingErrorConstructor(m.ErrorConstructor, classifiers...)
+	}))
+}
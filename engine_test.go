@@ -1,6 +1,7 @@
 package maleo
 
 import (
+	"context"
 	"reflect"
 	"runtime"
 	"testing"
@@ -39,6 +40,44 @@ func TestNewEngine(t *testing.T) {
 	compareFunction(t, mockErrorMessageContextConstructor, eng.ErrorMessageContextConstructor)
 }
 
+// constructorEngine implements only the constructors of Engine, like Engines written before the optional interfaces.
+type constructorEngine struct {
+	ErrorConstructor
+	EntryConstructor
+	EntryMessageContextConstructor
+	ErrorMessageContextConstructor
+}
+
+func TestMaleo_SetEngine_ConstructorsOnly(t *testing.T) {
+	logger := &countingLogger{}
+	messenger := &recordingMessenger{}
+	m := New(Service{}, Option.Init().Logger(logger).Messengers(messenger))
+	m.SetEngine(constructorEngine{
+		ErrorConstructor:               ErrorConstructorFunc(defaultErrorGenerator),
+		EntryConstructor:               EntryConstructorFunc(defaultEntryConstructor),
+		EntryMessageContextConstructor: MessageContextConstructorFunc(defaultMessageContextConstructor),
+		ErrorMessageContextConstructor: ErrorMessageConstructorFunc(defaultErrorMessageContextConstructor),
+	})
+	ctx := context.Background()
+
+	err := m.Bail("not found").Code(404).Context("password", "hunter2").Log(ctx).Notify(ctx)
+	if logger.count != 1 {
+		t.Errorf("expected the error to be logged once, got %d", logger.count)
+	}
+	if len(messenger.messages) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(messenger.messages))
+	}
+	if got := Query.GetHTTPCode(err); got != 404 {
+		t.Errorf("expected the default HTTP code mapping to give 404, got %d", got)
+	}
+	if got, want := m.Fingerprint(messenger.messages[0]), defaultFingerprint(messenger.messages[0]); got != want {
+		t.Errorf("expected the default fingerprint %q, got %q", want, got)
+	}
+	if got := err.Context(); !reflect.DeepEqual(got, []any{"password", "hunter2"}) {
+		t.Errorf("expected the context to stay as is without a Redactor, got %v", got)
+	}
+}
+
 func compareFunction(t *testing.T, expected, actual interface{}) {
 	expect := runtime.FuncForPC(reflect.ValueOf(expected).Pointer()).Name()
 	actualFunc := runtime.FuncForPC(reflect.ValueOf(actual).Pointer()).Name()
@@ -0,0 +1,124 @@
+package maleo
+
+import (
+	"os"
+	"runtime/debug"
+)
+
+type enricher struct{}
+
+// Enricher holds the built-in Hooks that add fields to the ContextFields of every Entry and Error. Fields set by the
+// caller with the same names take precedence.
+//
+// Example:
+//
+//	maleo.Global().SetEngine(maleo.NewEngine(maleo.Option.Engine().Hooks(
+//		maleo.Enricher.Hostname(),
+//		maleo.Enricher.Kubernetes(),
+//		maleo.Enricher.VCSRevision(),
+//	)))
+var Enricher enricher
+
+// KubernetesEnv maps the environment variables commonly set by the Kubernetes downward API to the field names used by
+// Enricher.Kubernetes.
+var KubernetesEnv = map[string]string{
+	"POD_NAME":      "pod_name",
+	"POD_NAMESPACE": "pod_namespace",
+	"POD_IP":        "pod_ip",
+	"POD_UID":       "pod_uid",
+	"NODE_NAME":     "node_name",
+}
+
+var readBuildInfo = debug.ReadBuildInfo
+
+var _ Hook = fieldsHook{}
+
+type fieldsHook struct {
+	NoopHook
+	fields Fields
+}
+
+// HookEntry adds the fields to the entry. The fields set by the caller take precedence.
+func (f fieldsHook) HookEntry(_ *EntryConstructorContext, builder EntryBuilder) EntryBuilder {
+	if len(f.fields) == 0 {
+		return builder
+	}
+	if b, ok := builder.(*entryBuilder); ok {
+		b.fields = mergeFields(f.fields, b.fields)
+		return b
+	}
+	return builder.Fields(f.fields)
+}
+
+// HookError adds the fields to the error. The fields set by the caller take precedence.
+func (f fieldsHook) HookError(_ *ErrorConstructorContext, builder ErrorBuilder) ErrorBuilder {
+	if len(f.fields) == 0 {
+		return builder
+	}
+	if b, ok := builder.(*errorBuilder); ok {
+		b.fields = mergeFields(f.fields, b.fields)
+		return b
+	}
+	return builder.Fields(f.fields)
+}
+
+// Fields adds the fields to every Entry and Error.
+func (enricher) Fields(fields Fields) Hook {
+	return fieldsHook{fields: fields}
+}
+
+// Hostname adds the hostname of the machine as the "hostname" field. Nothing is added if the hostname cannot be
+// read.
+func (enricher) Hostname() Hook {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		return fieldsHook{}
+	}
+	return fieldsHook{fields: Fields{"hostname": hostname}}
+}
+
+// ProcessID adds the process ID as the "pid" field.
+func (enricher) ProcessID() Hook {
+	return fieldsHook{fields: Fields{"pid": os.Getpid()}}
+}
+
+// Kubernetes adds the environment variables listed in KubernetesEnv, e.g. the pod name and namespace exposed by the
+// downward API. Unset variables are skipped.
+//
+// The variables are read once, when the Hook is created.
+func (enricher) Kubernetes() Hook {
+	fields := Fields{}
+	for env, key := range KubernetesEnv {
+		if value := os.Getenv(env); value != "" {
+			fields[key] = value
+		}
+	}
+	return fieldsHook{fields: fields}
+}
+
+// VCSRevision adds the version control revision the binary is built from, as the "vcs_revision" field. The
+// "vcs_modified" field is added if the working tree had uncommitted changes.
+//
+// The revision is read from debug.ReadBuildInfo, which is only available for binaries built with module support from a
+// version controlled directory. Nothing is added otherwise.
+func (enricher) VCSRevision() Hook {
+	info, ok := readBuildInfo()
+	if !ok {
+		return fieldsHook{}
+	}
+	fields := Fields{}
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			fields["vcs_revision"] = setting.Value
+		case "vcs.modified":
+			if setting.Value == "true" {
+				fields["vcs_modified"] = true
+			}
+		}
+	}
+	if _, ok := fields["vcs_revision"]; !ok {
+		return fieldsHook{}
+	}
+	return fieldsHook{fields: fields}
+}
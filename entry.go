@@ -10,6 +10,11 @@ type EntryBuilder interface {
 	// Code Sets the code for this entry.
 	Code(i int) EntryBuilder
 
+	// HTTPCode Sets the HTTP status code for this entry, independent of the code.
+	//
+	// If not set, the HTTP code is derived from the code by the HTTPCodeMapper of the Engine.
+	HTTPCode(i int) EntryBuilder
+
 	// Message Sets the message for this entry.
 	//
 	// In built in implementation, If args are supplied, fmt.Sprintf will be called with s as base string.
@@ -29,6 +34,12 @@ type EntryBuilder interface {
 	// 	maleo.NewEntry(msg).Code(200).Context(maleo.F{"foo": "bar"}).Freeze()
 	Context(ctx ...any) EntryBuilder
 
+	// Fields Adds fields to the ContextFields of this entry. They take precedence over the fields inherited from
+	// Maleo.With and maleo.ContextWithFields.
+	//
+	// In built-in implementation, additional call to .Fields() will merge the fields, where the latter take precedence.
+	Fields(fields Fields) EntryBuilder
+
 	// Key Sets the key for this entry. This is how Messenger will use to identify if an entry is the same as previous or not.
 	//
 	// In maleo's built-in implementation, by default, no key is set when creating new entry.
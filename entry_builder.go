@@ -7,14 +7,18 @@ import (
 )
 
 type entryBuilder struct {
-	code    int
-	message string
-	caller  Caller
-	context []any
-	key     string
-	level   Level
-	time    time.Time
-	maleo   *Maleo
+	code     int
+	httpCode int
+	message  string
+	caller   Caller
+	context  []any
+	fields   Fields
+	key      string
+	level    Level
+	time     time.Time
+	maleo    *Maleo
+	dropped  bool
+	hook     func(EntryBuilder) EntryBuilder
 }
 
 func (e *entryBuilder) Code(i int) EntryBuilder {
@@ -22,6 +26,11 @@ func (e *entryBuilder) Code(i int) EntryBuilder {
 	return e
 }
 
+func (e *entryBuilder) HTTPCode(i int) EntryBuilder {
+	e.httpCode = i
+	return e
+}
+
 func (e *entryBuilder) Time(t time.Time) EntryBuilder {
 	e.time = t
 	return e
@@ -41,6 +50,11 @@ func (e *entryBuilder) Context(ctx ...any) EntryBuilder {
 	return e
 }
 
+func (e *entryBuilder) Fields(fields Fields) EntryBuilder {
+	e.fields = mergeFields(e.fields, fields)
+	return e
+}
+
 func (e *entryBuilder) Key(key string, args ...any) EntryBuilder {
 	e.key = key
 	return e
@@ -57,7 +71,10 @@ func (e *entryBuilder) Level(lvl Level) EntryBuilder {
 }
 
 func (e *entryBuilder) Freeze() Entry {
-	return EntryNode{e}
+	if hooked := e.runHook(); hooked != nil {
+		return hooked.Freeze()
+	}
+	return EntryNode{inner: e, values: &cachedValues{}, fields: &cachedFields{}}
 }
 
 func (e *entryBuilder) Log(ctx context.Context) Entry {
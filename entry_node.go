@@ -11,7 +11,10 @@ var _ Entry = (*EntryNode)(nil)
 
 // EntryNode is the default implementation of Entry for Maleo.
 type EntryNode struct {
-	inner *entryBuilder
+	inner         *entryBuilder
+	contextFields Fields
+	values        *cachedValues
+	fields        *cachedFields
 }
 
 // MarshalJSON implements the json.Marshaler interface.
@@ -20,14 +23,16 @@ func (e EntryNode) MarshalJSON() ([]byte, error) {
 	enc := json.NewEncoder(b)
 	enc.SetEscapeHTML(false)
 	err := enc.Encode(implJsonMarshaler{
-		Time:    e.Time().Format(time.RFC3339),
-		Code:    e.Code(),
-		Message: e.Message(),
-		Caller:  e.Caller(),
-		Key:     e.Key(),
-		Level:   e.Level().String(),
-		Service: &e.inner.maleo.service,
-		Context: e.Context(),
+		Time:          e.Time().Format(time.RFC3339),
+		Code:          e.Code(),
+		Message:       e.Message(),
+		Caller:        e.Caller(),
+		Key:           e.Key(),
+		Level:         e.Level().String(),
+		Component:     e.Component(),
+		Service:       &e.inner.maleo.service,
+		ContextFields: e.ContextFields(),
+		Context:       e.Context(),
 	})
 	return b.Bytes(), err
 }
@@ -48,17 +53,14 @@ func (e EntryNode) Service() Service {
 }
 
 // HTTPCode return HTTP Status Code for the type.
+//
+// The HTTP code set by EntryBuilder.HTTPCode is used first. Otherwise, the HTTP code is mapped from the code by the
+// HTTPCodeMapper of the Engine, defaulting to 200.
 func (e EntryNode) HTTPCode() int {
-	switch {
-	case e.inner.code >= 200 && e.inner.code <= 599:
-		return e.inner.code
-	case e.inner.code > 999:
-		code := e.inner.code % 1000
-		if code >= 200 && code <= 599 {
-			return code
-		}
+	if e.inner.httpCode != 0 {
+		return e.inner.httpCode
 	}
-	return 200
+	return e.inner.maleo.mapHTTPCode(e.inner.code, 200)
 }
 
 // Message returns the message.
@@ -76,9 +78,26 @@ func (e EntryNode) Caller() Caller {
 	return e.inner.caller
 }
 
-// Context returns the context of the entry.
+// Context returns the context of the entry. Sensitive data is masked and the size is
+// limited by the Redactor and Truncator of the Engine, once per entry.
 func (e EntryNode) Context() []any {
-	return e.inner.context
+	return e.values.get(func() []any {
+		return e.inner.maleo.contextValues(e.inner.context)
+	})
+}
+
+// ContextFields returns the fields inherited from Maleo.With,
+// merged with the fields attached by maleo.ContextWithFields when the entry is logged or notified, and the fields set
+// by the builder.
+func (e EntryNode) ContextFields() Fields {
+	return e.fields.get(func() Fields {
+		return e.inner.maleo.contextFields(mergeFields(mergeFields(e.inner.maleo.fields, e.contextFields), e.inner.fields))
+	})
+}
+
+// Component returns the component name of the Maleo instance that created the entry.
+func (e EntryNode) Component() string {
+	return e.inner.maleo.component
 }
 
 // Level Gets the level of this message.
@@ -33,8 +33,8 @@ func TestEntryNode(t *testing.T) {
 	if node.HTTPCode() != 200 {
 		t.Errorf("Expected entry node http code to be 200, got %d", node.HTTPCode())
 	}
-	if node.Service().String() != "test-test-test-test" {
-		t.Errorf("Expected entry node service to be test-test-test-test, got %s", node.Service().String())
+	if node.Service().String() != "test-v0.1.0-test-test-test" {
+		t.Errorf("Expected entry node service to be test-v0.1.0-test-test-test, got %s", node.Service().String())
 	}
 	builder.Code(500)
 	node = builder.Freeze()
@@ -11,6 +11,10 @@ type ErrorConstructorContext struct {
 	Caller         Caller
 	MessageAndArgs []any
 	Maleo          *Maleo
+	// Stack is the captured program counters of the stack trace, starting from Caller.
+	//
+	// Stack is nil when stack trace capture is not enabled for the Maleo instance.
+	Stack []uintptr
 }
 
 type ErrorConstructor interface {
@@ -53,6 +57,7 @@ func defaultErrorGenerator(ctx *ErrorConstructorContext) ErrorBuilder {
 		origin:  ctx.Err,
 		maleo:   ctx.Maleo,
 		time:    time.Now(),
+		stack:   ctx.Stack,
 	}
 }
 
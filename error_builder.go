@@ -19,11 +19,22 @@ type ErrorBuilder interface {
 	// This is used to identify the error type and how maleohttp will interact with this error.
 	Code(i int) ErrorBuilder
 
+	// HTTPCode Sets the HTTP status code for this error, independent of the code.
+	//
+	// If not set, the HTTP code is derived from the code by the HTTPCodeMapper of the Engine.
+	HTTPCode(i int) ErrorBuilder
+
 	// Message Overrides the error message for this error.
 	//
 	// In built in implementation, If args are supplied, fmt.Sprintf will be called with s as base string.
 	Message(s string, args ...any) ErrorBuilder
 
+	// PublicMessage Sets the message that is safe to show to clients, e.g. in HTTP responses.
+	// Logs and Messengers keep using the Message.
+	//
+	// In built in implementation, If args are supplied, fmt.Sprintf will be called with s as base string.
+	PublicMessage(s string, args ...any) ErrorBuilder
+
 	// Error Sets the origin error for ErrorBuilder. Very unlikely to need to set this because Maleo.Wrap already uses the error.
 	// But the api is available to set the origin error.
 	Error(err error) ErrorBuilder
@@ -39,6 +50,12 @@ type ErrorBuilder interface {
 	// 	maleo.Wrap(err).Code(400).Context("count", 123, "username", "kilua", "ranking", 5).Freeze()
 	Context(ctx ...any) ErrorBuilder
 
+	// Fields Adds fields to the ContextFields of this error. They take precedence over the fields inherited from
+	// Maleo.With and maleo.ContextWithFields.
+	//
+	// In built-in implementation, additional call to .Fields() will merge the fields, where the latter take precedence.
+	Fields(fields Fields) ErrorBuilder
+
 	// Key Sets the key for this error. This is how the Messengers will use to identify if an error is the same as previous or not.
 	//
 	// Usually by not setting the key, The Messenger will generate their own.
@@ -66,15 +83,21 @@ type ErrorBuilder interface {
 }
 
 type errorBuilder struct {
-	code    int
-	message string
-	caller  Caller
-	context []any
-	key     string
-	level   Level
-	origin  error
-	maleo   *Maleo
-	time    time.Time
+	code          int
+	httpCode      int
+	message       string
+	publicMessage string
+	caller        Caller
+	context       []any
+	fields        Fields
+	key           string
+	level         Level
+	origin        error
+	maleo         *Maleo
+	time          time.Time
+	stack         []uintptr
+	dropped       bool
+	hook          func(ErrorBuilder) ErrorBuilder
 }
 
 func (e *errorBuilder) Level(lvl Level) ErrorBuilder {
@@ -92,6 +115,11 @@ func (e *errorBuilder) Code(i int) ErrorBuilder {
 	return e
 }
 
+func (e *errorBuilder) HTTPCode(i int) ErrorBuilder {
+	e.httpCode = i
+	return e
+}
+
 func (e *errorBuilder) Error(err error) ErrorBuilder {
 	if err == nil {
 		err = ErrNil
@@ -109,11 +137,25 @@ func (e *errorBuilder) Message(s string, args ...any) ErrorBuilder {
 	return e
 }
 
+func (e *errorBuilder) PublicMessage(s string, args ...any) ErrorBuilder {
+	if len(args) > 0 {
+		e.publicMessage = fmt.Sprintf(s, args...)
+	} else {
+		e.publicMessage = s
+	}
+	return e
+}
+
 func (e *errorBuilder) Context(ctx ...any) ErrorBuilder {
 	e.context = append(e.context, ctx...)
 	return e
 }
 
+func (e *errorBuilder) Fields(fields Fields) ErrorBuilder {
+	e.fields = mergeFields(e.fields, fields)
+	return e
+}
+
 func (e *errorBuilder) Key(key string, args ...any) ErrorBuilder {
 	if len(args) > 0 {
 		e.key = fmt.Sprintf(key, args...)
@@ -129,7 +171,10 @@ func (e *errorBuilder) Time(t time.Time) ErrorBuilder {
 }
 
 func (e *errorBuilder) Freeze() Error {
-	node := &ErrorNode{inner: e}
+	if hooked := e.runHook(); hooked != nil {
+		return hooked.Freeze()
+	}
+	node := &ErrorNode{inner: e, values: &cachedValues{}, fields: &cachedFields{}}
 	if child, ok := e.origin.(*ErrorNode); ok {
 		node.next = child
 		child.prev = node
@@ -0,0 +1,114 @@
+package maleo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+)
+
+// Classification is the code, HTTP code and level given to an error by an ErrorClassifier.
+type Classification struct {
+	Code int
+	// HTTPCode is the HTTP code of the error. If zero, the HTTP code follows the code.
+	HTTPCode int
+	Level    Level
+}
+
+// ErrorClassifier classifies errors passed to Maleo.Wrap. Returns false if the classifier does not know the error.
+type ErrorClassifier interface {
+	Classify(err error) (Classification, bool)
+}
+
+type ErrorClassifierFunc func(err error) (Classification, bool)
+
+func (f ErrorClassifierFunc) Classify(err error) (Classification, bool) {
+	return f(err)
+}
+
+// ErrorClassifiers tries the classifiers in order, and returns the result of the first classifier that knows the error.
+type ErrorClassifiers []ErrorClassifier
+
+func (e ErrorClassifiers) Classify(err error) (Classification, bool) {
+	for _, c := range e {
+		if class, ok := c.Classify(err); ok {
+			return class, true
+		}
+	}
+	return Classification{}, false
+}
+
+// ClassifyIs classifies errors that match target by errors.Is.
+func ClassifyIs(target error, class Classification) ErrorClassifier {
+	return ErrorClassifierFunc(func(err error) (Classification, bool) {
+		return class, errors.Is(err, target)
+	})
+}
+
+// ClassifyAs classifies errors that have T in the chain, found by errors.As.
+func ClassifyAs[T error](class Classification) ErrorClassifier {
+	return ErrorClassifierFunc(func(err error) (Classification, bool) {
+		var target T
+		return class, errors.As(err, &target)
+	})
+}
+
+/*
+StdlibClassifiers returns the classifiers for well-known errors of the standard library:
+
+  - context.Canceled: 499, WarnLevel
+  - context.DeadlineExceeded: 504, ErrorLevel
+  - sql.ErrNoRows: 404, WarnLevel
+  - os.ErrNotExist: 404, WarnLevel
+  - *json.SyntaxError and *json.UnmarshalTypeError: 400, WarnLevel
+  - net.Error with Timeout() true: 504, ErrorLevel
+*/
+func StdlibClassifiers() ErrorClassifiers {
+	return ErrorClassifiers{
+		ClassifyIs(context.Canceled, Classification{Code: 499, Level: WarnLevel}),
+		ClassifyIs(context.DeadlineExceeded, Classification{Code: 504, Level: ErrorLevel}),
+		ClassifyIs(sql.ErrNoRows, Classification{Code: 404, Level: WarnLevel}),
+		ClassifyIs(os.ErrNotExist, Classification{Code: 404, Level: WarnLevel}),
+		ClassifyAs[*json.SyntaxError](Classification{Code: 400, Level: WarnLevel}),
+		ClassifyAs[*json.UnmarshalTypeError](Classification{Code: 400, Level: WarnLevel}),
+		ErrorClassifierFunc(func(err error) (Classification, bool) {
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				return Classification{Code: 504, Level: ErrorLevel}, true
+			}
+			return Classification{}, false
+		}),
+	}
+}
+
+/*
+ClassifyingErrorConstructor wraps the ErrorConstructor, so errors without code hints in the chain get the code and
+level from the classifiers. Errors with code hints, like wrapped maleo Errors, keep their code and level.
+
+Codes and levels set on the returned ErrorBuilder still override the classification.
+*/
+func ClassifyingErrorConstructor(next ErrorConstructor, classifiers ...ErrorClassifier) ErrorConstructor {
+	classifier := ErrorClassifiers(classifiers)
+	return ErrorConstructorFunc(func(ctx *ErrorConstructorContext) ErrorBuilder {
+		builder := next.ConstructError(ctx)
+		if ctx.Err == nil || hasCodeHint(ctx.Err) {
+			return builder
+		}
+		if class, ok := classifier.Classify(ctx.Err); ok {
+			builder = builder.Code(class.Code).Level(class.Level)
+			if class.HTTPCode != 0 {
+				builder = builder.HTTPCode(class.HTTPCode)
+			}
+		}
+		return builder
+	})
+}
+
+func hasCodeHint(err error) bool {
+	return find(err, func(err error) bool {
+		_, ok := err.(CodeHint) //nolint:errorlint
+		return ok
+	}) != nil
+}
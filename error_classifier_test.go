@@ -0,0 +1,71 @@
+package maleo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+var (
+	errPaymentDeclined = errors.New("payment declined")
+	errQuotaExceeded   = errors.New("quota exceeded")
+)
+
+func TestClassifyingErrorConstructor(t *testing.T) {
+	_, open := os.Open("/does/not/exist")
+	var syntax *json.SyntaxError
+	if err := json.Unmarshal([]byte("{"), &struct{}{}); !errors.As(err, &syntax) {
+		t.Fatalf("expected json.SyntaxError, got %v", err)
+	}
+
+	m := New(Service{Name: "test"})
+	m.SetEngine(NewEngine(Option.Engine().ErrorClassifiers(
+		ClassifyIs(errPaymentDeclined, Classification{Code: 402, Level: InfoLevel}),
+		ClassifyIs(errQuotaExceeded, Classification{Code: 42901, HTTPCode: 429, Level: WarnLevel}),
+		StdlibClassifiers(),
+	)))
+	tests := []struct {
+		name      string
+		err       error
+		wantCode  int
+		wantHTTP  int
+		wantLevel Level
+	}{
+		{"canceled", fmt.Errorf("query: %w", context.Canceled), 499, 499, WarnLevel},
+		{"deadline", context.DeadlineExceeded, 504, 504, ErrorLevel},
+		{"no rows", fmt.Errorf("get user: %w", sql.ErrNoRows), 404, 404, WarnLevel},
+		{"not exist", open, 404, 404, WarnLevel},
+		{"json syntax", syntax, 400, 400, WarnLevel},
+		{"net timeout", fmt.Errorf("dial: %w", timeoutError{}), 504, 504, ErrorLevel},
+		{"custom", fmt.Errorf("charge: %w", errPaymentDeclined), 402, 402, InfoLevel},
+		{"custom http code", fmt.Errorf("upload: %w", errQuotaExceeded), 42901, 429, WarnLevel},
+		{"unknown", errors.New("boom"), 500, 500, ErrorLevel},
+		{"code hint wins", m.Wrap(context.Canceled).Code(409).Freeze(), 409, 409, ErrorLevel},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := m.WrapFreeze(tt.err)
+			if err.Code() != tt.wantCode || err.HTTPCode() != tt.wantHTTP || err.Level() != tt.wantLevel {
+				t.Errorf("code = %d, http code = %d, level = %v, want %d, %d, %v",
+					err.Code(), err.HTTPCode(), err.Level(), tt.wantCode, tt.wantHTTP, tt.wantLevel)
+			}
+		})
+	}
+
+	if err := m.Wrap(context.Canceled).Code(400).Freeze(); err.Code() != 400 {
+		t.Errorf("explicit code must override the classification, got %d", err.Code())
+	}
+	if err := New(Service{}).WrapFreeze(context.Canceled); err.Code() != 500 {
+		t.Errorf("classifiers must be opt-in, got code %d", err.Code())
+	}
+}
@@ -13,9 +13,11 @@ const codeBlockIndent = "   "
 
 // ErrorNode is the implementation of the Error interface.
 type ErrorNode struct {
-	inner *errorBuilder
-	prev  *ErrorNode
-	next  *ErrorNode
+	inner  *errorBuilder
+	prev   *ErrorNode
+	next   *ErrorNode
+	values *cachedValues
+	fields *cachedFields
 }
 
 // sorted keys are rather important for human reads. Especially the Context and Error should always be at the last marshaled keys.
@@ -24,28 +26,50 @@ type ErrorNode struct {
 // arguably this is simpler to be done than implementing json.Marshaler interface and doing it manually, key by key
 // without resorting to other libraries.
 type implJsonMarshaler struct {
-	Time    string   `json:"time,omitempty"`
-	Code    int      `json:"code,omitempty"`
-	Message string   `json:"message,omitempty"`
-	Caller  Caller   `json:"caller,omitempty"`
-	Key     string   `json:"key,omitempty"`
-	Level   string   `json:"level,omitempty"`
-	Service *Service `json:"service,omitempty"`
-	Context []any    `json:"context,omitempty"`
-	Error   error    `json:"error,omitempty"`
+	Time          string   `json:"time,omitempty"`
+	Code          int      `json:"code,omitempty"`
+	Message       string   `json:"message,omitempty"`
+	PublicMessage string   `json:"public_message,omitempty"`
+	Caller        Caller   `json:"caller,omitempty"`
+	Stack         []Caller `json:"stack,omitempty"`
+	Key           string   `json:"key,omitempty"`
+	Level         string   `json:"level,omitempty"`
+	Component     string   `json:"component,omitempty"`
+	Service       *Service `json:"service,omitempty"`
+	ContextFields Fields   `json:"context_fields,omitempty"`
+	Context       []any    `json:"context,omitempty"`
+	Error         error    `json:"error,omitempty"`
 }
 
 func newImplJSONMarshaler(e Error, next error, ctx []any, service *Service) implJsonMarshaler {
+	var (
+		stack         []Caller
+		contextFields Fields
+		component     string
+	)
+	if sh, ok := e.(StackHint); ok {
+		stack = sh.Frames()
+	}
+	if cf, ok := e.(ContextFieldsHint); ok {
+		contextFields = cf.ContextFields()
+	}
+	if ch, ok := e.(ComponentHint); ok {
+		component = ch.Component()
+	}
 	return implJsonMarshaler{
-		Time:    e.Time().Format(time.RFC3339),
-		Code:    e.Code(),
-		Message: e.Message(),
-		Caller:  e.Caller(),
-		Key:     e.Key(),
-		Level:   e.Level().String(),
-		Context: ctx,
-		Error:   richJsonError{next},
-		Service: service,
+		Time:          e.Time().Format(time.RFC3339),
+		Code:          e.Code(),
+		Message:       e.Message(),
+		PublicMessage: publicMessage(e),
+		Caller:        e.Caller(),
+		Stack:         stack,
+		Key:           e.Key(),
+		Level:         e.Level().String(),
+		Context:       ctx,
+		Error:         richJsonError{next},
+		Service:       service,
+		ContextFields: contextFields,
+		Component:     component,
 	}
 }
 
@@ -57,7 +81,7 @@ func (im implJsonMarshaler) MarshalJSON() ([]byte, error) {
 	}
 	a := A{Alias(im), im.Context}
 	if len(im.Context) > 1 {
-		a.Context = toMap(im.Context)
+		a.Context = ContextMap(im.Context)
 	} else if len(im.Context) == 0 {
 		a.Context = nil
 	} else {
@@ -160,7 +184,7 @@ func (e *ErrorNode) createPayload(m marshalFlag) *implJsonMarshaler {
 	} else {
 		next = e.inner.origin
 	}
-	marshalAble := newImplJSONMarshaler(e, next, e.inner.context, &e.inner.maleo.service)
+	marshalAble := newImplJSONMarshaler(e, next, e.Context(), &e.inner.maleo.service)
 
 	if m.Has(marshalSkipCode) {
 		marshalAble.Code = 0
@@ -176,17 +200,33 @@ func (e *ErrorNode) createPayload(m marshalFlag) *implJsonMarshaler {
 	}
 	if m.Has(marshalSkipCaller) {
 		marshalAble.Caller = nil
+		marshalAble.Stack = nil
 	}
 	if m.Has(marshalSkipService) {
 		marshalAble.Service = nil
 	}
+	if e.sharesFieldsWithPrev() {
+		marshalAble.ContextFields = nil
+		marshalAble.Component = ""
+	}
 	return &marshalAble
 }
 
+// sharesFieldsWithPrev reports whether the error is nested in an error from the same Maleo instance, which has the same
+// inherited fields and component.
+func (e *ErrorNode) sharesFieldsWithPrev() bool {
+	return e.prev != nil && e.prev.inner.maleo == e.inner.maleo
+}
+
 func (e *ErrorNode) MarshalJSON() ([]byte, error) {
 	if e == nil {
 		return []byte("null"), nil
 	}
+	return e.marshalJSON(nil)
+}
+
+// marshalJSON marshals the error. Non-nil contextFields replace the ContextFields of the error.
+func (e *ErrorNode) marshalJSON(contextFields Fields) ([]byte, error) {
 	m := e.createMarshalJSONFlag()
 	b := &bytes.Buffer{}
 	enc := json.NewEncoder(b)
@@ -195,7 +235,11 @@ func (e *ErrorNode) MarshalJSON() ([]byte, error) {
 		err := enc.Encode(richJsonError{e.inner.origin})
 		return b.Bytes(), err
 	}
-	err := enc.Encode(e.createPayload(m))
+	payload := e.createPayload(m)
+	if contextFields != nil && !e.sharesFieldsWithPrev() {
+		payload.ContextFields = contextFields
+	}
+	err := enc.Encode(payload)
 	return b.Bytes(), err
 }
 
@@ -264,17 +308,35 @@ func (e *ErrorNode) Code() int {
 }
 
 // HTTPCode Gets HTTP Status Code for the type.
+//
+// The HTTP code set by ErrorBuilder.HTTPCode is used first. Otherwise, if the error is built from a Definition with the
+// same code, the HTTP code of the Definition is used. Otherwise, the HTTP code is mapped from the code by the
+// HTTPCodeMapper of the Engine, defaulting to 500.
 func (e *ErrorNode) HTTPCode() int {
-	switch {
-	case e.inner.code >= 200 && e.inner.code <= 599:
-		return e.inner.code
-	case e.inner.code > 999:
-		code := e.inner.code % 1000
-		if code >= 200 && code <= 599 {
-			return code
-		}
+	if e.inner.httpCode != 0 {
+		return e.inner.httpCode
 	}
-	return 500
+	if def, ok := e.definition(); ok {
+		return def.HTTPCode()
+	}
+	return e.inner.maleo.mapHTTPCode(e.inner.code, 500)
+}
+
+func (e *ErrorNode) isHTTPCodeDerived() bool {
+	if e.inner.httpCode != 0 {
+		return false
+	}
+	_, ok := e.definition()
+	return !ok
+}
+
+// definition returns the Definition the error is built from, if the code is not changed since.
+func (e *ErrorNode) definition() (*Definition, bool) {
+	def, ok := FindDefinition(e.inner.origin)
+	if !ok || def.Code() != e.inner.code {
+		return nil, false
+	}
+	return def, true
 }
 
 // Message Gets the Message of the type.
@@ -282,14 +344,68 @@ func (e *ErrorNode) Message() string {
 	return e.inner.message
 }
 
+func publicMessage(e Error) string {
+	if pm, ok := e.(PublicMessageHint); ok {
+		return pm.PublicMessage()
+	}
+	return ""
+}
+
+// PublicMessage Gets the message of this error that is safe to show to clients. Returns empty string if not set.
+//
+// Use Query.GetPublicMessage to search the whole error chain.
+func (e *ErrorNode) PublicMessage() string {
+	return e.inner.publicMessage
+}
+
 // Caller Gets the caller of this type.
 func (e *ErrorNode) Caller() Caller {
 	return e.inner.caller
 }
 
-// Context Gets the context of this type.
+// ContextFields returns the fields inherited from Maleo.With, merged with the fields set by the builder.
+//
+// The fields attached by maleo.ContextWithFields are added by the MessageContext when the error is notified, and
+// passed to the Logger along the error when it is logged.
+func (e *ErrorNode) ContextFields() Fields {
+	return e.fields.get(func() Fields {
+		return e.contextFieldsWith(nil)
+	})
+}
+
+// contextFieldsWith returns the ContextFields of the error with the request scoped fields added. The fields set by the
+// builder take precedence over the request scoped fields, which take precedence over the inherited fields.
+func (e *ErrorNode) contextFieldsWith(fields Fields) Fields {
+	return e.inner.maleo.contextFields(mergeFields(mergeFields(e.inner.maleo.fields, fields), e.inner.fields))
+}
+
+// Component returns the component name of the Maleo instance that created the error.
+func (e *ErrorNode) Component() string {
+	return e.inner.maleo.component
+}
+
+// Frames implements StackHint. Returns nil if stack trace capture is not enabled for the Maleo instance
+// or the level of this error.
+func (e *ErrorNode) Frames() []Caller {
+	if len(e.inner.stack) == 0 {
+		return nil
+	}
+	m := e.inner.maleo
+	if m == nil {
+		return callersFromPCs(e.inner.stack, DefaultFrameFilter)
+	}
+	if m.stackLevel == nil || !m.stackLevel.Enabled(e.inner.level) {
+		return nil
+	}
+	return callersFromPCs(e.inner.stack, m.stackFilter)
+}
+
+// Context Gets the context of this type. Sensitive data is masked and the size is
+// limited by the Redactor and Truncator of the Engine, once per error.
 func (e *ErrorNode) Context() []any {
-	return e.inner.context
+	return e.values.get(func() []any {
+		return e.inner.maleo.contextValues(e.inner.context)
+	})
 }
 
 func (e *ErrorNode) Level() Level {
@@ -333,6 +449,11 @@ type richJsonError struct {
 	error
 }
 
+type joinedJsonError[T any] struct {
+	Summary string `json:"summary"`
+	Errors  []T    `json:"errors"`
+}
+
 func (r richJsonError) MarshalJSON() ([]byte, error) {
 	if r.error == nil {
 		return []byte("null"), nil
@@ -344,6 +465,21 @@ func (r richJsonError) MarshalJSON() ([]byte, error) {
 	if e, ok := r.error.(json.Marshaler); ok { //nolint
 		return e.MarshalJSON()
 	}
+	// Joined errors render every branch so none of them are lost in the output.
+	if e, ok := r.error.(interface{ Unwrap() []error }); ok { //nolint
+		errs := e.Unwrap()
+		branches := make([]richJsonError, 0, len(errs))
+		for _, err := range errs {
+			if err != nil {
+				branches = append(branches, richJsonError{err})
+			}
+		}
+		b := &bytes.Buffer{}
+		enc := json.NewEncoder(b)
+		enc.SetEscapeHTML(false)
+		err := enc.Encode(joinedJsonError[richJsonError]{Summary: r.error.Error(), Errors: branches})
+		return b.Bytes(), err
+	}
 	b := &bytes.Buffer{}
 	enc := json.NewEncoder(b)
 	enc.SetEscapeHTML(false)
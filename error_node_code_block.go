@@ -34,6 +34,19 @@ func (c cbJson) MarshalJSON() ([]byte, error) {
 	enc := json.NewEncoder(b)
 	enc.SetEscapeHTML(false)
 	enc.SetIndent("", codeBlockIndent)
+	if _, ok := c.inner.(json.Marshaler); !ok {
+		if e, ok := c.inner.(interface{ Unwrap() []error }); ok {
+			errs := e.Unwrap()
+			branches := make([]cbJson, 0, len(errs))
+			for _, err := range errs {
+				if err != nil {
+					branches = append(branches, cbJson{err})
+				}
+			}
+			err := enc.Encode(joinedJsonError[cbJson]{Summary: c.inner.Error(), Errors: branches})
+			return b.Bytes(), err
+		}
+	}
 	err := enc.Encode(richJsonError{c.inner})
 	return b.Bytes(), err
 }
@@ -125,7 +138,11 @@ func (e *ErrorNode) deduplicateAgainstOtherError(other Error) marshalFlag {
 	return m
 }
 
-func toMap(v []any) map[string]any {
+// ContextMap turns the Context values of Entry or Error into a map, where every key is followed by its value. Keys that
+// are not strings are formatted with fmt.Sprint, and pairs with an empty key or a nil value are skipped.
+//
+// Use it in Loggers and Messengers that render the Context as a single object.
+func ContextMap(v []any) map[string]any {
 	var (
 		key   string
 		value any
@@ -163,9 +180,10 @@ func (e *ErrorNode) createCodeBlockPayload(m marshalFlag) *implJsonMarshaler {
 		Code:    e.Code(),
 		Message: e.Message(),
 		Caller:  e.Caller(),
+		Stack:   e.Frames(),
 		Key:     e.Key(),
 		Level:   e.Level().String(),
-		Context: e.inner.context,
+		Context: e.Context(),
 		Error:   cbJson{next},
 		Service: &e.inner.maleo.service,
 	}
@@ -184,6 +202,7 @@ func (e *ErrorNode) createCodeBlockPayload(m marshalFlag) *implJsonMarshaler {
 	}
 	if m.Has(marshalSkipCaller) {
 		marshalAble.Caller = nil
+		marshalAble.Stack = nil
 	}
 	if m.Has(marshalSkipService) {
 		marshalAble.Service = nil
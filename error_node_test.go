@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -504,6 +505,53 @@ func TestError_WrapMarshalJSON(t *testing.T) {
 	}`)
 }
 
+func TestError_JoinedMarshalJSON(t *testing.T) {
+	mal, _ := NewTestingMaleo()
+	err := mal.Wrap(joinError{errors.New("a"), mockError{Message: "b"}}).Message("joined").Freeze()
+	b, errMarshal := json.Marshal(err)
+	if errMarshal != nil {
+		t.Fatalf("Expected error to marshal to JSON without error, got %v", errMarshal)
+	}
+	j := jsonassert.New(t)
+	j.Assertf(string(b), `
+	{
+		"time": "<<PRESENCE>>",
+		"code": 500,
+		"message": "joined",
+		"caller": "<<PRESENCE>>",
+		"level": "error",
+		"service": "<<PRESENCE>>",
+		"error": {
+			"summary": "a\nb",
+			"errors": [
+				{"summary": "a"},
+				{"summary": "b", "details": {"Message": "b"}}
+			]
+		}
+	}`)
+
+	b, errMarshal = err.(CodeBlockJSONMarshaler).CodeBlockJSON() //nolint:errorlint
+	if errMarshal != nil {
+		t.Fatalf("Expected error to marshal to code block JSON without error, got %v", errMarshal)
+	}
+	j.Assertf(string(b), `
+	{
+		"time": "<<PRESENCE>>",
+		"code": 500,
+		"message": "joined",
+		"caller": "<<PRESENCE>>",
+		"level": "error",
+		"service": "<<PRESENCE>>",
+		"error": {
+			"summary": "a\nb",
+			"errors": [
+				{"summary": "a"},
+				{"summary": "b", "details": {"Message": "b"}}
+			]
+		}
+	}`)
+}
+
 func Test_Error_WriteError(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -565,3 +613,57 @@ func Test_Error_WriteError(t *testing.T) {
 		})
 	}
 }
+
+func TestErrorNode_Frames(t *testing.T) {
+	mal := New(Service{}, Option.Init().StackTrace(ErrorLevel))
+	err := func() Error { return mal.Bail("deep").Freeze() }()
+
+	sh, ok := err.(StackHint) //nolint:errorlint
+	if !ok {
+		t.Fatal("expected ErrorNode to implement StackHint")
+	}
+	frames := sh.Frames()
+	if len(frames) != 2 {
+		t.Fatalf("expected 2 frames, got %v", frames)
+	}
+	if frames[0].ShortName() != "maleo.TestErrorNode_Frames.func1" {
+		t.Errorf("expected first frame to be maleo.TestErrorNode_Frames.func1, got %s", frames[0].ShortName())
+	}
+	if frames[0].String() != err.Caller().String() {
+		t.Errorf("expected first frame %s to be the caller %s", frames[0], err.Caller())
+	}
+	if frames[1].ShortName() != "maleo.TestErrorNode_Frames" {
+		t.Errorf("expected second frame to be maleo.TestErrorNode_Frames, got %s", frames[1].ShortName())
+	}
+
+	b, errMarshal := json.Marshal(err)
+	if errMarshal != nil {
+		t.Fatalf("Expected error to marshal to JSON without error, got %v", errMarshal)
+	}
+	var out struct {
+		Stack []struct {
+			Function string `json:"function"`
+		} `json:"stack"`
+	}
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	if len(out.Stack) != 2 || out.Stack[1].Function != "maleo.TestErrorNode_Frames" {
+		t.Errorf("unexpected stack in JSON: %s", b)
+	}
+
+	if frames := mal.Bail("warn").Level(WarnLevel).Freeze().(StackHint).Frames(); frames != nil { //nolint:errorlint
+		t.Errorf("expected no frames for level below the stack trace level, got %v", frames)
+	}
+	if frames := Bail("global").Freeze().(StackHint).Frames(); frames != nil { //nolint:errorlint
+		t.Errorf("expected no frames when stack trace is not enabled, got %v", frames)
+	}
+}
+
+func TestContextMap(t *testing.T) {
+	got := ContextMap([]any{"user", "foo", 42, "answer", "", "skipped"})
+	want := map[string]any{"user": "foo", "42": "answer"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ContextMap() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,226 @@
+package maleo
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EscalationStore keeps the counters of Escalation. The Lockers of the locker module implement it, so they can be
+// passed to NewEscalation as they are.
+type EscalationStore interface {
+	// Set the key and value.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Get the Value by Key.
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Exist Checks if key exist in the store.
+	Exist(ctx context.Context, key string) bool
+	// Separator Returns Accepted separator value for the store.
+	Separator() string
+}
+
+// EscalationStep escalates the messages whose key fires Threshold times within Window.
+type EscalationStep struct {
+	// Name identifies the step in the counter keys and in the "escalation" field of the escalated message.
+	Name string
+	// Threshold is the number of messages with the same key within Window that triggers the step.
+	Threshold int
+	// Window is the duration the messages are counted in. The window slides: the count covers the last Window before
+	// each message.
+	Window time.Duration
+	// Level raises the level of the escalated message to at least this level.
+	Level Level
+	// Messengers are the names of the Messengers the escalated message is also sent to, usually benched ones.
+	// The Messengers are looked up from both the registered and the benched Messengers.
+	Messengers []string
+}
+
+/*
+Escalation escalates repeated messages instead of only slowing them down. Every message sent by Notify is counted by
+its key, and when the count of a key reaches the Threshold of an EscalationStep within its Window, that message is sent
+to the Messengers of the step as well, with its level raised to the Level of the step.
+
+The window slides. Messages are counted per fixed window, and the count within the last Window is estimated from the
+counts of the current and the previous fixed window, the latter weighted by how much of it is still within the last
+Window. So repeats that straddle the boundary of fixed windows still escalate.
+
+Each step escalates at most once per Window and key. Use a longer Window and a higher Threshold for later steps, e.g. to
+page someone when the error keeps firing.
+
+The counters live in the EscalationStore, usually a Locker, so all instances of a service sharing the same store share
+the counters. Counters are keyed by the name and environment of the Service. The counting is not atomic: a counter is
+read and then written, so concurrent messages of the same key may lose some counts, and a step may rarely escalate
+twice in a Window.
+
+The counting runs in Notify, so the store calls of one message are bounded by the timeout of the Escalation, 250ms by
+default. When the timeout passes or the store fails, the message is sent as is.
+
+Example:
+
+	escalation := maleo.NewEscalation(redisLocker,
+		maleo.EscalationStep{
+			Name:       "team",
+			Threshold:  10,
+			Window:     time.Minute,
+			Level:      maleo.ErrorLevel,
+			Messengers: []string{"discord-team"},
+		},
+		maleo.EscalationStep{
+			Name:       "pager",
+			Threshold:  100,
+			Window:     30 * time.Minute,
+			Level:      maleo.FatalLevel,
+			Messengers: []string{"pagerduty"},
+		},
+	)
+	m := maleo.New(service, maleo.Option.Init().Escalation(escalation))
+*/
+type Escalation struct {
+	store   EscalationStore
+	steps   []EscalationStep
+	now     func() time.Time
+	timeout time.Duration
+}
+
+// NewEscalation creates a new Escalation that keeps the counters in store, e.g. a locker.Locker. Steps with Threshold or
+// Window below 1 are ignored.
+func NewEscalation(store EscalationStore, steps ...EscalationStep) *Escalation {
+	valid := make([]EscalationStep, 0, len(steps))
+	for _, step := range steps {
+		if step.Threshold > 0 && step.Window > 0 {
+			valid = append(valid, step)
+		}
+	}
+	return &Escalation{store: store, steps: valid, now: time.Now, timeout: 250 * time.Millisecond}
+}
+
+// SetTimeout sets how long the store calls for one message may take in total. A timeout below 1 disables the limit.
+func (e *Escalation) SetTimeout(timeout time.Duration) {
+	e.timeout = timeout
+}
+
+// Steps returns the steps of the Escalation.
+func (e *Escalation) Steps() []EscalationStep {
+	return e.steps
+}
+
+// escalate counts msg and applies the steps that are triggered by it to params. Returns the escalated message, or msg
+// as is if no step is triggered.
+func (e *Escalation) escalate(ctx context.Context, m *Maleo, msg MessageContext, params *MessageParameters) MessageContext {
+	if len(e.steps) == 0 {
+		return msg
+	}
+	key := m.MessageKey(msg)
+	service := msg.Service()
+	now := e.now()
+
+	if e.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.timeout)
+		defer cancel()
+	}
+	var (
+		triggered []string
+		level     = msg.Level()
+	)
+	for _, step := range e.steps {
+		count, err := e.count(ctx, service, step, key, now)
+		if err != nil {
+			return msg
+		}
+		if count < float64(step.Threshold) {
+			continue
+		}
+		fired := e.key("fired", service.Name, service.Environment, step.Name, key)
+		if e.store.Exist(ctx, fired) {
+			continue
+		}
+		// Exist reports false on failures too, which must not escalate the message again.
+		if ctx.Err() != nil {
+			return msg
+		}
+		if err := e.store.Set(ctx, fired, []byte{1}, step.Window); err != nil {
+			return msg
+		}
+		triggered = append(triggered, step.Name)
+		found, _ := lookupMessengers(step.Messengers, params.Messengers, params.Benched)
+		params.Messengers = appendMissingMessengers(params.Messengers, found)
+		if step.Level > level {
+			level = step.Level
+		}
+	}
+	if len(triggered) == 0 {
+		return msg
+	}
+	if level != msg.Level() {
+		msg = MessageWithLevel(msg, level)
+	}
+	return MessageWithFields(msg, F{"escalation": strings.Join(triggered, ",")})
+}
+
+// count counts the message in the fixed window that now falls in, and returns the estimated count of the last Window
+// of the step: the count of the current fixed window, plus the count of the previous one weighted by the part of it
+// that is still within the last Window.
+func (e *Escalation) count(
+	ctx context.Context, service Service, step EscalationStep, key string, now time.Time,
+) (float64, error) {
+	start := now.Truncate(step.Window)
+	counter := func(start time.Time) string {
+		window := strconv.FormatInt(start.UnixMilli(), 10)
+		return e.key("count", service.Name, service.Environment, step.Name, key, window)
+	}
+	// Counters live for two windows, so the previous window can still be read.
+	current, err := e.increment(ctx, counter(start), 2*step.Window)
+	if err != nil {
+		return 0, err
+	}
+	previous, err := e.get(ctx, counter(start.Add(-step.Window)))
+	if err != nil {
+		return 0, err
+	}
+	overlap := 1 - float64(now.Sub(start))/float64(step.Window)
+	return float64(current) + float64(previous)*overlap, nil
+}
+
+// key builds the store key from the parts.
+func (e *Escalation) key(parts ...string) string {
+	return strings.Join(append([]string{"maleo", "escalation"}, parts...), e.store.Separator())
+}
+
+// get returns the counter under key, or 0 if it does not exist.
+func (e *Escalation) get(ctx context.Context, key string) (int, error) {
+	b, err := e.store.Get(ctx, key)
+	if err != nil {
+		// Get fails on missing keys too, which only count as errors when the context ends.
+		return 0, ctx.Err()
+	}
+	count, _ := strconv.Atoi(string(b))
+	return count, nil
+}
+
+// increment reads and writes the counter under key. It is not atomic, see Escalation.
+func (e *Escalation) increment(ctx context.Context, key string, ttl time.Duration) (int, error) {
+	count, err := e.get(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+	count++
+	if err := e.store.Set(ctx, key, []byte(strconv.Itoa(count)), ttl); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// escalate applies the Escalation of this instance, if any.
+func (m *Maleo) escalate(ctx context.Context, msg MessageContext, opts *MessageParameters) MessageContext {
+	if m.escalation == nil {
+		return msg
+	}
+	return m.escalation.escalate(DetachedContext(ctx), m, msg, opts)
+}
+
+// SetEscalation sets the Escalation of repeated messages. Pass nil to disable escalation.
+func (m *Maleo) SetEscalation(escalation *Escalation) {
+	m.escalation = escalation
+}
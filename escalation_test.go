@@ -0,0 +1,211 @@
+package maleo
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+type storeValue struct {
+	value   []byte
+	expires time.Time
+}
+
+// memoryStore is an EscalationStore that expires the keys by the clock of the test.
+type memoryStore struct {
+	mu     sync.Mutex
+	now    func() time.Time
+	values map[string]storeValue
+}
+
+func newMemoryStore(now func() time.Time) *memoryStore {
+	return &memoryStore{now: now, values: map[string]storeValue{}}
+}
+
+func (s *memoryStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values[key] = storeValue{value: value, expires: s.now().Add(ttl)}
+	return nil
+}
+
+func (s *memoryStore) Get(_ context.Context, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.values[key]
+	if !ok || !s.now().Before(v.expires) {
+		return nil, errors.New("not found")
+	}
+	return v.value, nil
+}
+
+func (s *memoryStore) Exist(ctx context.Context, key string) bool {
+	_, err := s.Get(ctx, key)
+	return err == nil
+}
+
+func (s *memoryStore) Separator() string { return ":" }
+
+type namedMessenger struct {
+	recordingMessenger
+	name string
+}
+
+func (n *namedMessenger) Name() string { return n.name }
+
+func TestEscalation(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	escalation := NewEscalation(newMemoryStore(func() time.Time { return now }),
+		EscalationStep{Name: "team", Threshold: 3, Window: time.Minute, Level: ErrorLevel, Messengers: []string{"team"}},
+		EscalationStep{Name: "pager", Threshold: 5, Window: time.Hour, Level: FatalLevel, Messengers: []string{"pager"}},
+	)
+	escalation.now = func() time.Time { return now }
+
+	defaults := &namedMessenger{name: "default"}
+	team := &namedMessenger{name: "team"}
+	pager := &namedMessenger{name: "pager"}
+	m := New(Service{Name: "api"}, Option.Init().Messengers(defaults).Escalation(escalation))
+	m.RegisterBenched(team, pager)
+	ctx := context.Background()
+
+	notify := func(n int) {
+		for i := 0; i < n; i++ {
+			_ = m.Bail("db down").Key("db-down").Level(WarnLevel).Notify(ctx)
+		}
+	}
+
+	notify(3)
+	if len(team.messages) != 1 || len(pager.messages) != 0 {
+		t.Fatalf("team received %d, pager received %d, want 1 and 0", len(team.messages), len(pager.messages))
+	}
+	msg := team.messages[0]
+	if msg.Level() != ErrorLevel {
+		t.Errorf("escalated level = %v, want %v", msg.Level(), ErrorLevel)
+	}
+	if got := msg.(ContextFieldsHint).ContextFields()["escalation"]; got != "team" {
+		t.Errorf("escalation field = %v, want team", got)
+	}
+
+	notify(1)
+	if len(team.messages) != 1 {
+		t.Errorf("team received %d messages, want the step to escalate once per window", len(team.messages))
+	}
+
+	now = now.Add(30 * time.Second)
+	notify(1)
+	if len(pager.messages) != 1 || pager.messages[0].Level() != FatalLevel {
+		t.Fatalf("pager received %v, want one message with fatal level", pager.messages)
+	}
+	if len(team.messages) != 1 {
+		t.Errorf("team received %d messages, want 1", len(team.messages))
+	}
+
+	// The earlier messages of the team step are out of its window, but the pager window keeps counting.
+	now = now.Add(3 * time.Minute)
+	notify(2)
+	if len(team.messages) != 1 {
+		t.Errorf("team received %d messages, want 1 before the threshold of the new window", len(team.messages))
+	}
+	notify(1)
+	if len(team.messages) != 2 {
+		t.Errorf("team received %d messages, want 2 after a new window", len(team.messages))
+	}
+	if len(pager.messages) != 1 {
+		t.Errorf("pager received %d messages, want 1 within the hour", len(pager.messages))
+	}
+	if len(defaults.messages) != 8 {
+		t.Errorf("default received %d messages, want every message", len(defaults.messages))
+	}
+	for _, msg := range defaults.messages[:2] {
+		if msg.Level() != WarnLevel {
+			t.Errorf("level before escalation = %v, want %v", msg.Level(), WarnLevel)
+		}
+	}
+}
+
+func TestEscalation_SlidingWindow(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 50, 0, time.UTC)
+	escalation := NewEscalation(newMemoryStore(func() time.Time { return now }),
+		EscalationStep{Name: "team", Threshold: 4, Window: time.Minute, Messengers: []string{"team"}},
+	)
+	escalation.now = func() time.Time { return now }
+	team := &namedMessenger{name: "team"}
+	m := New(Service{Name: "api"}, Option.Init().Escalation(escalation))
+	m.RegisterBenched(team)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		_ = m.Bail("db down").Key("db-down").Notify(ctx)
+	}
+	// 15 seconds later, across the start of the next minute.
+	now = now.Add(15 * time.Second)
+	_ = m.Bail("db down").Key("db-down").Notify(ctx)
+	if len(team.messages) != 0 {
+		t.Fatalf("team received %d messages, want 0 below the threshold", len(team.messages))
+	}
+	_ = m.Bail("db down").Key("db-down").Notify(ctx)
+	if len(team.messages) != 1 {
+		t.Errorf("team received %d messages, want repeats across the minute to escalate", len(team.messages))
+	}
+
+	// After the step may escalate again, the first repeats are out of the window.
+	now = now.Add(65 * time.Second)
+	_ = m.Bail("db down").Key("db-down").Notify(ctx)
+	_ = m.Bail("db down").Key("db-down").Notify(ctx)
+	if len(team.messages) != 1 {
+		t.Errorf("team received %d messages, want old repeats to leave the window", len(team.messages))
+	}
+}
+
+func TestEscalation_SharedStore(t *testing.T) {
+	shared := newMemoryStore(time.Now)
+	step := EscalationStep{Name: "team", Threshold: 2, Window: time.Minute, Messengers: []string{"team"}}
+	team := &namedMessenger{name: "team"}
+	a := New(Service{Name: "api"}, Option.Init().Escalation(NewEscalation(shared, step)))
+	b := New(Service{Name: "api"}, Option.Init().Escalation(NewEscalation(shared, step)))
+	other := New(Service{Name: "worker"}, Option.Init().Escalation(NewEscalation(shared, step)))
+	for _, m := range []*Maleo{a, b, other} {
+		m.RegisterBenched(team)
+	}
+	ctx := context.Background()
+
+	_ = a.Bail("failed").Key("failed").Notify(ctx)
+	_ = other.Bail("failed").Key("failed").Notify(ctx)
+	if len(team.messages) != 0 {
+		t.Fatalf("team received %d messages, want 0 for different services", len(team.messages))
+	}
+	_ = b.Bail("failed").Key("failed").Notify(ctx)
+	if len(team.messages) != 1 {
+		t.Errorf("team received %d messages, want 1 from the shared counter", len(team.messages))
+	}
+}
+
+type slowStore struct {
+	EscalationStore
+}
+
+func (s slowStore) Get(ctx context.Context, _ string) ([]byte, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestEscalation_Timeout(t *testing.T) {
+	escalation := NewEscalation(slowStore{newMemoryStore(time.Now)},
+		EscalationStep{Name: "team", Threshold: 1, Window: time.Minute, Level: ErrorLevel, Messengers: []string{"team"}},
+	)
+	escalation.SetTimeout(10 * time.Millisecond)
+	team := &namedMessenger{name: "team"}
+	m := New(Service{Name: "api"}, Option.Init().Messengers(&namedMessenger{name: "default"}).Escalation(escalation))
+	m.RegisterBenched(team)
+
+	start := time.Now()
+	_ = m.Bail("db down").Key("db-down").Notify(context.Background())
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Notify took %v, want the store calls bounded by the timeout", elapsed)
+	}
+	if len(team.messages) != 0 {
+		t.Errorf("team received %d messages, want none when the store times out", len(team.messages))
+	}
+}
@@ -72,3 +72,21 @@ func WrapFreeze(err error, msgAndArgs ...any) Error {
 func BailFreeze(msg string, args ...any) Error {
 	return Global().BailFreeze(msg, args...)
 }
+
+// Fatal works like Wrap, but the Error has FatalLevel. Calling .Log(ctx) on it notifies every Messenger, waits for them,
+// and exits the program with code 1.
+//
+// Example:
+//
+//	if err := db.Ping(ctx); err != nil {
+//	  maleo.Fatal(err, "failed to connect to database").Log(ctx)
+//	}
+func Fatal(err error, msgAndArgs ...any) ErrorBuilder {
+	return Global().Fatal(err, msgAndArgs...)
+}
+
+// Panic works like Wrap, but the Error has PanicLevel. Calling .Log(ctx) on it notifies every Messenger, waits for them,
+// and panics with the Error.
+func Panic(err error, msgAndArgs ...any) ErrorBuilder {
+	return Global().Panic(err, msgAndArgs...)
+}
@@ -60,8 +60,8 @@ func (f Fields) WriteSummary(w LineWriter) {
 			v.WriteSummary(NewLineWriter(w).Indent("  ").Build())
 		case Summary:
 			_, _ = w.WriteString(v.Summary())
-		case fmt.Stringer:
-			_, _ = w.WriteString(v.String())
+		// json.RawMessage implements fmt.Stringer when built with GOEXPERIMENT=jsonv2, where it is an alias of
+		// jsontext.Value.
 		case json.RawMessage:
 			if len(v) <= 32 {
 				s := strconv.Quote(string(v))
@@ -69,6 +69,8 @@ func (f Fields) WriteSummary(w LineWriter) {
 			} else {
 				_, _ = w.WriteString("[...]")
 			}
+		case fmt.Stringer:
+			_, _ = w.WriteString(v.String())
 		case []byte:
 			if len(v) <= 32 {
 				s := strconv.Quote(string(v))
@@ -91,3 +93,23 @@ func (f Fields) WriteSummary(w LineWriter) {
 		w.WriteSuffix()
 	}
 }
+
+// mergeFields returns the union of the given fields, where the latter take precedence.
+//
+// The input is returned as is when there's nothing to merge with.
+func mergeFields(base, override Fields) Fields {
+	if len(override) == 0 {
+		return base
+	}
+	if len(base) == 0 {
+		return override
+	}
+	out := make(Fields, len(base)+len(override))
+	for k, v := range base {
+		out[k] = v
+	}
+	for k, v := range override {
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,94 @@
+package maleo
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Fingerprinter builds the key of messages that have no Key set. Messengers use the key to group repeated messages,
+// e.g. for cooldowns.
+type Fingerprinter interface {
+	Fingerprint(msg MessageContext) string
+}
+
+type FingerprinterFunc func(msg MessageContext) string
+
+func (f FingerprinterFunc) Fingerprint(msg MessageContext) string {
+	return f(msg)
+}
+
+var (
+	fingerprintUUID   = regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`)
+	fingerprintHex    = regexp.MustCompile(`\b(0x)?[0-9a-fA-F]{8,}\b`)
+	fingerprintNumber = regexp.MustCompile(`\d+`)
+)
+
+// NormalizeMessage masks the variable parts of a message, like UUIDs, hex ids and numbers,
+// so messages that only differ by those parts are considered the same.
+func NormalizeMessage(s string) string {
+	s = fingerprintUUID.ReplaceAllString(s, "<uuid>")
+	s = fingerprintHex.ReplaceAllStringFunc(s, func(v string) string {
+		// Words made of hex letters only, like "deadbeef", are kept.
+		if strings.IndexAny(v, "0123456789") < 0 {
+			return v
+		}
+		return "<id>"
+	})
+	return fingerprintNumber.ReplaceAllString(s, "<n>")
+}
+
+/*
+defaultFingerprint builds the key from:
+
+  - the function name of the caller, so moving code around in a function keeps the key;
+  - the normalized message;
+  - the type and normalized message of the root cause of the error, if any.
+
+The key is the short function name followed by a hash of the parts.
+*/
+func defaultFingerprint(msg MessageContext) string {
+	var name string
+	if c := msg.Caller(); c != nil {
+		name = c.Name()
+		if name == "" {
+			name = c.ShortSource()
+		}
+	}
+	parts := []string{name, NormalizeMessage(msg.Message())}
+	if err := msg.Err(); err != nil {
+		root := Query.Cause(err)
+		parts = append(parts, fmt.Sprintf("%T", root), NormalizeMessage(root.Error()))
+	}
+	sum := sha1.Sum([]byte(strings.Join(parts, "\n")))
+
+	s := &strings.Builder{}
+	if short := shortFunctionName(name); short != "" {
+		replaceSymbols(s, short, '_')
+		s.WriteRune('_')
+	}
+	s.WriteString(hex.EncodeToString(sum[:8]))
+	return s.String()
+}
+
+func shortFunctionName(name string) string {
+	s := strings.Split(name, "/")
+	return s[len(s)-1]
+}
+
+// Fingerprint returns the key built by the Fingerprinter of the engine for the message.
+func (m *Maleo) Fingerprint(msg MessageContext) string {
+	return m.fingerprinter().Fingerprint(msg)
+}
+
+// MessageKey returns the Key of the message, or the fingerprint of the message if the Key is not set.
+//
+// Messengers should use this to group repeated messages.
+func (m *Maleo) MessageKey(msg MessageContext) string {
+	if key := msg.Key(); key != "" {
+		return key
+	}
+	return m.Fingerprint(msg)
+}
@@ -0,0 +1,84 @@
+package maleo
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeMessage(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"user 42 not found", "user <n> not found"},
+		{"order 3f2c8a1e-9b4d-4c2a-8f1e-1a2b3c4d5e6f failed", "order <uuid> failed"},
+		{"object 507f1f77bcf86cd799439011 missing", "object <id> missing"},
+		{"pointer 0x7ffd5e8c at deadbeef", "pointer <id> at deadbeef"},
+		{"timeout after 30s", "timeout after <n>s"},
+	}
+	for _, tt := range tests {
+		if got := NormalizeMessage(tt.in); got != tt.want {
+			t.Errorf("NormalizeMessage(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func notifyAndCapture(notify func(m *Maleo)) MessageContext {
+	m, _ := NewTestingMaleo()
+	inner := &recordingMessenger{}
+	m.Register(inner)
+	notify(m)
+	return inner.messages[0]
+}
+
+func TestMaleo_Fingerprint(t *testing.T) {
+	ctx := context.Background()
+	m, _ := NewTestingMaleo()
+	queryUser := func(id string, cause error, otherLine bool) MessageContext {
+		return notifyAndCapture(func(m *Maleo) {
+			if otherLine {
+				_ = m.Wrap(cause).Message("failed to query user %s", id).Notify(ctx)
+				return
+			}
+			_ = m.Wrap(cause).Message("failed to query user %s", id).Notify(ctx)
+		})
+	}
+
+	a := m.Fingerprint(queryUser("1", errors.New("row 1 not found"), false))
+	b := m.Fingerprint(queryUser("2", errors.New("row 2 not found"), true))
+	if a != b {
+		t.Errorf("messages that differ by ids and lines must share the fingerprint, got %q and %q", a, b)
+	}
+	if !strings.HasPrefix(a, "maleo.TestMaleo_Fingerprint.func") {
+		t.Errorf("fingerprint %q must start with the function name", a)
+	}
+	if c := m.Fingerprint(queryUser("3", io.EOF, false)); c == a {
+		t.Errorf("messages with different root causes must not share the fingerprint, got %q", c)
+	}
+	if d := m.Fingerprint(queryUser("4", errors.New("connection refused"), false)); d == a {
+		t.Errorf("messages with different root cause messages must not share the fingerprint, got %q", d)
+	}
+	if e := m.Fingerprint(queryUser("5", joinError{errors.New("row 5 not found"), io.EOF}, false)); e != a {
+		t.Errorf("joined errors must use the root cause of the first branch, got %q and %q", e, a)
+	}
+
+	keyed := notifyAndCapture(func(m *Maleo) { m.NewEntry("hello").Key("greeting").Notify(ctx) })
+	if got := m.MessageKey(keyed); got != "greeting" {
+		t.Errorf("MessageKey() = %q, want the key of the message", got)
+	}
+	unkeyed := notifyAndCapture(func(m *Maleo) { m.NewEntry("hello").Notify(ctx) })
+	if got := m.MessageKey(unkeyed); got != m.Fingerprint(unkeyed) {
+		t.Errorf("MessageKey() = %q, want the fingerprint", got)
+	}
+
+	custom := New(Service{})
+	custom.SetEngine(NewEngine(Option.Engine().Fingerprinter(FingerprinterFunc(func(MessageContext) string {
+		return "custom"
+	}))))
+	if got := custom.MessageKey(unkeyed); got != "custom" {
+		t.Errorf("MessageKey() with custom Fingerprinter = %q, want %q", got, "custom")
+	}
+}
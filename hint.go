@@ -17,11 +17,33 @@ type CallerHint interface {
 	Caller() Caller
 }
 
+type StackHint interface {
+	// Frames returns the stack trace of this type, from the innermost call to the outermost.
+	Frames() []Caller
+}
+
+type ContextFieldsHint interface {
+	// ContextFields returns the fields inherited from Maleo.With,
+	// merged with the request scoped fields attached by maleo.ContextWithFields.
+	ContextFields() Fields
+}
+
+type ComponentHint interface {
+	// Component returns the component name set by Maleo.Named.
+	Component() string
+}
+
 type MessageHint interface {
 	// Message returns the message of the type.
 	Message() string
 }
 
+type PublicMessageHint interface {
+	// PublicMessage returns the message that is safe to show to clients, e.g. in HTTP responses.
+	// Returns empty string if not set.
+	PublicMessage() string
+}
+
 type KeyHint interface {
 	// Key returns the key for this type.
 	Key() string
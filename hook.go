@@ -0,0 +1,258 @@
+package maleo
+
+import "context"
+
+/*
+Hook runs when Entries and Errors are frozen, and when MessageContexts are constructed. Use it to enrich every item
+with data that would otherwise have to be added at every call, e.g. the hostname or the pod name, to override values,
+or to drop items completely.
+
+Embed NoopHook to implement only some of the methods.
+*/
+type Hook interface {
+	// HookEntry runs when the entry is frozen, by Freeze, Log or Notify, after the caller sets the values of the
+	// entry. Use the builder to add Fields or Context, or to override the Key or Level.
+	//
+	// Return nil to drop the entry. Dropped entries are not logged nor sent to Messengers.
+	HookEntry(ctx *EntryConstructorContext, builder EntryBuilder) EntryBuilder
+	// HookError runs when the error is frozen, by Freeze, Log or Notify, after the caller sets the values of the
+	// error. Use the builder to add Fields or Context, or to override the Key or Level.
+	//
+	// Return nil to drop the error. Dropped errors are still returned to the caller, but they are not logged nor sent
+	// to Messengers.
+	HookError(ctx *ErrorConstructorContext, builder ErrorBuilder) ErrorBuilder
+	// HookMessage runs after the MessageContext is constructed, before it is routed and sent to Messengers. Use
+	// MessageWithFields, MessageWithKey and MessageWithLevel to override the values of the message.
+	//
+	// Return nil to drop the message.
+	HookMessage(msg MessageContext) MessageContext
+}
+
+// NoopHook returns the items as is.
+type NoopHook struct{}
+
+func (NoopHook) HookEntry(_ *EntryConstructorContext, builder EntryBuilder) EntryBuilder {
+	return builder
+}
+
+func (NoopHook) HookError(_ *ErrorConstructorContext, builder ErrorBuilder) ErrorBuilder {
+	return builder
+}
+
+func (NoopHook) HookMessage(msg MessageContext) MessageContext {
+	return msg
+}
+
+var _ Hook = (Hooks)(nil)
+
+// Hooks runs the hooks in order. The first hook that drops the item stops the rest from running.
+type Hooks []Hook
+
+func (h Hooks) HookEntry(ctx *EntryConstructorContext, builder EntryBuilder) EntryBuilder {
+	for _, hook := range h {
+		if builder = hook.HookEntry(ctx, builder); builder == nil {
+			return nil
+		}
+	}
+	return builder
+}
+
+func (h Hooks) HookError(ctx *ErrorConstructorContext, builder ErrorBuilder) ErrorBuilder {
+	for _, hook := range h {
+		if builder = hook.HookError(ctx, builder); builder == nil {
+			return nil
+		}
+	}
+	return builder
+}
+
+func (h Hooks) HookMessage(msg MessageContext) MessageContext {
+	for _, hook := range h {
+		if msg = hook.HookMessage(msg); msg == nil {
+			return nil
+		}
+	}
+	return msg
+}
+
+// droppedHint is implemented by the built-in Entry and Error.
+type droppedHint interface {
+	isDropped() bool
+}
+
+func (e EntryNode) isDropped() bool {
+	return e.inner.dropped
+}
+
+func (e *ErrorNode) isDropped() bool {
+	return e.inner.dropped
+}
+
+func isDropped(v any) bool {
+	d, ok := v.(droppedHint)
+	return ok && d.isDropped()
+}
+
+// constructEntry constructs an EntryBuilder with the engine, and sets the Hooks to run when the entry is frozen.
+//
+// Only the built-in builder defers the Hooks. The Hooks run on the builders of other EntryConstructors right away,
+// and entries dropped by them are kept.
+func (m *Maleo) constructEntry(ctx *EntryConstructorContext) EntryBuilder {
+	builder := m.engine.ConstructEntry(ctx)
+	hook := m.hook()
+	if b, ok := builder.(*entryBuilder); ok {
+		b.hook = func(builder EntryBuilder) EntryBuilder {
+			return hook.HookEntry(ctx, builder)
+		}
+		return b
+	}
+	if hooked := hook.HookEntry(ctx, builder); hooked != nil {
+		return hooked
+	}
+	return builder
+}
+
+// constructError constructs an ErrorBuilder with the engine, and sets the Hooks to run when the error is frozen.
+//
+// Only the built-in builder defers the Hooks. The Hooks run on the builders of other ErrorConstructors right away,
+// and errors dropped by them are kept.
+func (m *Maleo) constructError(ctx *ErrorConstructorContext) ErrorBuilder {
+	builder := m.engine.ConstructError(ctx)
+	hook := m.hook()
+	if b, ok := builder.(*errorBuilder); ok {
+		b.hook = func(builder ErrorBuilder) ErrorBuilder {
+			return hook.HookError(ctx, builder)
+		}
+		return b
+	}
+	if hooked := hook.HookError(ctx, builder); hooked != nil {
+		return hooked
+	}
+	return builder
+}
+
+// runHook runs the Hooks set by constructEntry once. Returns the builder returned by the Hooks if it is not e, or nil
+// otherwise. Marks e dropped if the Hooks drop it.
+func (e *entryBuilder) runHook() EntryBuilder {
+	hook := e.hook
+	if hook == nil {
+		return nil
+	}
+	e.hook = nil
+	switch hooked := hook(e); hooked {
+	case nil:
+		e.dropped = true
+	case EntryBuilder(e):
+	default:
+		return hooked
+	}
+	return nil
+}
+
+// runHook runs the Hooks set by constructError once. Returns the builder returned by the Hooks if it is not e, or nil
+// otherwise. Marks e dropped if the Hooks drop it.
+func (e *errorBuilder) runHook() ErrorBuilder {
+	hook := e.hook
+	if hook == nil {
+		return nil
+	}
+	e.hook = nil
+	switch hooked := hook(e); hooked {
+	case nil:
+		e.dropped = true
+	case ErrorBuilder(e):
+	default:
+		return hooked
+	}
+	return nil
+}
+
+// buildEntryMessage builds the MessageContext of the entry and runs the Hooks on it. Returns nil if the entry or the
+// message is dropped.
+func (m *Maleo) buildEntryMessage(entry Entry, opts *MessageParameters) MessageContext {
+	if isDropped(entry) {
+		return nil
+	}
+	return m.hook().HookMessage(m.engine.BuildEntryMessageContext(entry, opts))
+}
+
+// buildErrorMessage builds the MessageContext of the error with the fields attached to ctx and runs the Hooks on it.
+// Returns nil if the error or the message is dropped.
+func (m *Maleo) buildErrorMessage(ctx context.Context, err Error, opts *MessageParameters) MessageContext {
+	if isDropped(err) {
+		return nil
+	}
+	return m.hook().HookMessage(messageWithContextFields(ctx, m.engine.BuildErrorMessageContext(err, opts)))
+}
+
+var (
+	_ MessageContext    = (*hookedMessageContext)(nil)
+	_ ComponentHint     = (*hookedMessageContext)(nil)
+	_ ContextFieldsHint = (*hookedMessageContext)(nil)
+)
+
+// hookedMessageContext overrides the values of a MessageContext set by Hooks.
+type hookedMessageContext struct {
+	MessageContext
+	fields Fields
+	key    *string
+	level  *Level
+}
+
+func hookMessage(msg MessageContext) *hookedMessageContext {
+	if h, ok := msg.(*hookedMessageContext); ok {
+		clone := *h
+		return &clone
+	}
+	return &hookedMessageContext{MessageContext: msg}
+}
+
+// MessageWithFields returns a copy of msg with the fields added to its ContextFields.
+func MessageWithFields(msg MessageContext, fields Fields) MessageContext {
+	h := hookMessage(msg)
+	h.fields = mergeFields(h.fields, fields)
+	return h
+}
+
+// MessageWithKey returns a copy of msg with the key overridden.
+func MessageWithKey(msg MessageContext, key string) MessageContext {
+	h := hookMessage(msg)
+	h.key = &key
+	return h
+}
+
+// MessageWithLevel returns a copy of msg with the level overridden.
+func MessageWithLevel(msg MessageContext, lvl Level) MessageContext {
+	h := hookMessage(msg)
+	h.level = &lvl
+	return h
+}
+
+func (h *hookedMessageContext) Key() string {
+	if h.key != nil {
+		return *h.key
+	}
+	return h.MessageContext.Key()
+}
+
+func (h *hookedMessageContext) Level() Level {
+	if h.level != nil {
+		return *h.level
+	}
+	return h.MessageContext.Level()
+}
+
+func (h *hookedMessageContext) Component() string {
+	if ch, ok := h.MessageContext.(ComponentHint); ok {
+		return ch.Component()
+	}
+	return ""
+}
+
+func (h *hookedMessageContext) ContextFields() Fields {
+	var fields Fields
+	if cf, ok := h.MessageContext.(ContextFieldsHint); ok {
+		fields = cf.ContextFields()
+	}
+	return mergeFields(fields, h.fields)
+}
@@ -0,0 +1,175 @@
+package maleo
+
+import (
+	"context"
+	"reflect"
+	"runtime/debug"
+	"testing"
+)
+
+type testHook struct {
+	NoopHook
+	dropEntry   bool
+	dropError   bool
+	dropMessage bool
+}
+
+func (h testHook) HookEntry(_ *EntryConstructorContext, builder EntryBuilder) EntryBuilder {
+	if h.dropEntry {
+		return nil
+	}
+	return builder.Key("hooked-entry").Level(WarnLevel)
+}
+
+func (h testHook) HookError(_ *ErrorConstructorContext, builder ErrorBuilder) ErrorBuilder {
+	if h.dropError {
+		return nil
+	}
+	return builder.Key("hooked-error")
+}
+
+func (h testHook) HookMessage(msg MessageContext) MessageContext {
+	if h.dropMessage {
+		return nil
+	}
+	return MessageWithLevel(MessageWithFields(msg, F{"region": "ap-southeast-1"}), ErrorLevel)
+}
+
+func newHookedMaleo(hooks ...Hook) (*Maleo, *countingLogger, *recordingMessenger) {
+	logger := &countingLogger{}
+	messenger := &recordingMessenger{}
+	m := New(Service{}, Option.Init().Logger(logger).Messengers(messenger).MessengerLevel("recording", DebugLevel))
+	m.SetEngine(NewEngine(Option.Engine().Hooks(hooks...)))
+	return m, logger, messenger
+}
+
+func TestHook(t *testing.T) {
+	m, logger, messenger := newHookedMaleo(Enricher.Fields(F{"pod_name": "api-0"}), testHook{})
+	ctx := context.Background()
+
+	entry := m.NewEntry("hello").Log(ctx).Notify(ctx)
+	if entry.Key() != "hooked-entry" || entry.Level() != WarnLevel {
+		t.Errorf("entry key = %q, level = %v, want hooked values", entry.Key(), entry.Level())
+	}
+	err := m.Bail("failed").Fields(F{"pod_name": "override"}).Log(ctx).Notify(ctx)
+	if err.Key() != "hooked-error" {
+		t.Errorf("error key = %q, want hooked-error", err.Key())
+	}
+	if got := err.(ContextFieldsHint).ContextFields(); !reflect.DeepEqual(got, F{"pod_name": "override"}) { //nolint:errorlint
+		t.Errorf("error context fields = %v, want the builder fields to take precedence", got)
+	}
+	if logger.count != 2 {
+		t.Errorf("logger count = %d, want 2", logger.count)
+	}
+	if len(messenger.messages) != 2 {
+		t.Fatalf("messenger received %d messages, want 2", len(messenger.messages))
+	}
+	msg := messenger.messages[0]
+	if msg.Level() != ErrorLevel || msg.Key() != "hooked-entry" {
+		t.Errorf("message level = %v, key = %q, want overridden level and the entry key", msg.Level(), msg.Key())
+	}
+	want := F{"pod_name": "api-0", "region": "ap-southeast-1"}
+	if got := msg.(ContextFieldsHint).ContextFields(); !reflect.DeepEqual(got, want) {
+		t.Errorf("message context fields = %v, want %v", got, want)
+	}
+}
+
+func TestHook_Drop(t *testing.T) {
+	ctx := context.Background()
+
+	m, logger, messenger := newHookedMaleo(testHook{dropEntry: true, dropError: true})
+	m.NewEntry("dropped").Log(ctx).Notify(ctx)
+	err := m.Bail("dropped").Log(ctx).Notify(ctx)
+	if err == nil || err.Error() != "dropped" {
+		t.Errorf("dropped error = %v, want it returned to the caller", err)
+	}
+	if explained := m.ExplainNotifyError(ctx, err); !explained.Dropped {
+		t.Errorf("ExplainNotifyError().Dropped = false, want true")
+	}
+	if logger.count != 0 || len(messenger.messages) != 0 {
+		t.Errorf("logger count = %d, messages = %d, want 0 each", logger.count, len(messenger.messages))
+	}
+
+	m, logger, messenger = newHookedMaleo(testHook{dropMessage: true})
+	m.NewEntry("logged").Log(ctx).Notify(ctx)
+	if logger.count != 1 || len(messenger.messages) != 0 {
+		t.Errorf("logger count = %d, messages = %d, want 1 and 0", logger.count, len(messenger.messages))
+	}
+}
+
+type keyDropHook struct {
+	NoopHook
+}
+
+func (keyDropHook) HookEntry(_ *EntryConstructorContext, builder EntryBuilder) EntryBuilder {
+	if builder.Freeze().Key() == "health-check" {
+		return nil
+	}
+	return builder
+}
+
+func (keyDropHook) HookError(_ *ErrorConstructorContext, builder ErrorBuilder) ErrorBuilder {
+	if builder.Freeze().Key() == "health-check" {
+		return nil
+	}
+	return builder
+}
+
+func TestHook_RunsAtFreeze(t *testing.T) {
+	ctx := context.Background()
+
+	m, _, _ := newHookedMaleo(testHook{})
+	entry := m.NewEntry("hello").Key("caller-key").Level(DebugLevel).Freeze()
+	if entry.Key() != "hooked-entry" || entry.Level() != WarnLevel {
+		t.Errorf("entry key = %q, level = %v, want the Hook to override the caller values", entry.Key(), entry.Level())
+	}
+	if err := m.Bail("failed").Key("caller-key").Freeze(); err.Key() != "hooked-error" {
+		t.Errorf("error key = %q, want the Hook to override the caller key", err.Key())
+	}
+
+	m, logger, messenger := newHookedMaleo(keyDropHook{})
+	m.NewEntry("ping").Key("health-check").Log(ctx).Notify(ctx)
+	_ = m.Bail("ping").Key("health-check").Log(ctx).Notify(ctx)
+	if logger.count != 0 || len(messenger.messages) != 0 {
+		t.Errorf("logger count = %d, messages = %d, want the items dropped by the caller key", logger.count, len(messenger.messages))
+	}
+	m.NewEntry("hello").Key("other").Log(ctx)
+	if logger.count != 1 {
+		t.Errorf("logger count = %d, want 1", logger.count)
+	}
+}
+
+func TestEnricher_Kubernetes(t *testing.T) {
+	t.Setenv("POD_NAME", "api-0")
+	t.Setenv("POD_NAMESPACE", "prod")
+	t.Setenv("NODE_NAME", "")
+
+	builder := &entryBuilder{}
+	_ = Enricher.Kubernetes().HookEntry(nil, builder)
+	if want := (F{"pod_name": "api-0", "pod_namespace": "prod"}); !reflect.DeepEqual(builder.fields, want) {
+		t.Errorf("fields = %v, want %v", builder.fields, want)
+	}
+}
+
+func TestEnricher_VCSRevision(t *testing.T) {
+	defer func(f func() (*debug.BuildInfo, bool)) { readBuildInfo = f }(readBuildInfo)
+	readBuildInfo = func() (*debug.BuildInfo, bool) {
+		return &debug.BuildInfo{Settings: []debug.BuildSetting{
+			{Key: "vcs.revision", Value: "abc123"},
+			{Key: "vcs.modified", Value: "true"},
+		}}, true
+	}
+
+	builder := &errorBuilder{}
+	_ = Enricher.VCSRevision().HookError(nil, builder)
+	if want := (F{"vcs_revision": "abc123", "vcs_modified": true}); !reflect.DeepEqual(builder.fields, want) {
+		t.Errorf("fields = %v, want %v", builder.fields, want)
+	}
+
+	readBuildInfo = func() (*debug.BuildInfo, bool) { return &debug.BuildInfo{}, true }
+	builder = &errorBuilder{}
+	_ = Enricher.VCSRevision().HookError(nil, builder)
+	if builder.fields != nil {
+		t.Errorf("fields = %v, want none without revision", builder.fields)
+	}
+}
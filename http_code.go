@@ -0,0 +1,74 @@
+package maleo
+
+// HTTPCodeMapper maps the business codes of Errors and Entries to HTTP status codes. It is used when no HTTP code is set
+// explicitly with ErrorBuilder.HTTPCode or EntryBuilder.HTTPCode.
+//
+// Returns false if the mapper does not know the code. Errors then fall back to 500 and Entries to 200.
+type HTTPCodeMapper interface {
+	MapHTTPCode(code int) (httpCode int, ok bool)
+}
+
+type HTTPCodeMapperFunc func(code int) (int, bool)
+
+func (f HTTPCodeMapperFunc) MapHTTPCode(code int) (int, bool) {
+	return f(code)
+}
+
+/*
+HTTPCodeMap maps business codes to HTTP status codes. Codes not in the map follow the default mapping.
+
+Example:
+
+	maleo.NewEngine(maleo.Option.Engine().HTTPCodeMapper(maleo.HTTPCodeMap{
+		40012: http.StatusBadRequest,
+		42901: http.StatusTooManyRequests,
+	}))
+*/
+type HTTPCodeMap map[int]int
+
+func (h HTTPCodeMap) MapHTTPCode(code int) (int, bool) {
+	if httpCode, ok := h[code]; ok {
+		return httpCode, true
+	}
+	return defaultHTTPCodeMapper(code)
+}
+
+/*
+defaultHTTPCodeMapper maps the codes by these rules:
+
+  - codes between 200 and 599 are used as is;
+  - codes above 999 use the last three digits if they are between 200 and 599, e.g. 7400 becomes 400.
+*/
+func defaultHTTPCodeMapper(code int) (int, bool) {
+	switch {
+	case code >= 200 && code <= 599:
+		return code, true
+	case code > 999:
+		code := code % 1000
+		if code >= 200 && code <= 599 {
+			return code, true
+		}
+	}
+	return 0, false
+}
+
+func httpCodeFromCode(code int) int {
+	if httpCode, ok := defaultHTTPCodeMapper(code); ok {
+		return httpCode
+	}
+	return 500
+}
+
+// mapHTTPCode maps the code with the HTTPCodeMapper of the engine, or with the default mapping if m is nil.
+func (m *Maleo) mapHTTPCode(code int, fallback int) int {
+	if httpCode, ok := m.httpCodeMapper().MapHTTPCode(code); ok {
+		return httpCode
+	}
+	return fallback
+}
+
+// derivedHTTPCode is implemented by the errors whose HTTP code may be derived from their business code instead of set
+// explicitly. Query.GetHTTPCode prefers the errors with explicit HTTP codes.
+type derivedHTTPCode interface {
+	isHTTPCodeDerived() bool
+}
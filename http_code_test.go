@@ -0,0 +1,80 @@
+package maleo
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestErrorBuilder_HTTPCode(t *testing.T) {
+	m, _ := NewTestingMaleo()
+	tests := []struct {
+		name string
+		err  Error
+		want int
+	}{
+		{
+			name: "no code",
+			err:  m.BailFreeze("foo"),
+			want: http.StatusInternalServerError,
+		},
+		{
+			name: "derived from code",
+			err:  m.Bail("foo").Code(7404).Freeze(),
+			want: http.StatusNotFound,
+		},
+		{
+			name: "unknown business code",
+			err:  m.Bail("foo").Code(40012).Freeze(),
+			want: http.StatusInternalServerError,
+		},
+		{
+			name: "explicit",
+			err:  m.Bail("foo").Code(40012).HTTPCode(http.StatusBadRequest).Freeze(),
+			want: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.HTTPCode(); got != tt.want {
+				t.Errorf("HTTPCode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if got := m.Bail("foo").Code(40012).HTTPCode(http.StatusBadRequest).Freeze().Code(); got != 40012 {
+		t.Errorf("Code() = %v, want %v", got, 40012)
+	}
+}
+
+func TestEntryBuilder_HTTPCode(t *testing.T) {
+	m, _ := NewTestingMaleo()
+	if got := m.NewEntry("foo").Freeze().HTTPCode(); got != http.StatusOK {
+		t.Errorf("HTTPCode() = %v, want %v", got, http.StatusOK)
+	}
+	if got := m.NewEntry("foo").Code(20101).Freeze().HTTPCode(); got != http.StatusOK {
+		t.Errorf("HTTPCode() = %v, want %v", got, http.StatusOK)
+	}
+	if got := m.NewEntry("foo").Code(20101).HTTPCode(http.StatusCreated).Freeze().HTTPCode(); got != http.StatusCreated {
+		t.Errorf("HTTPCode() = %v, want %v", got, http.StatusCreated)
+	}
+}
+
+func TestHTTPCodeMap(t *testing.T) {
+	m, _ := NewTestingMaleo()
+	m.SetEngine(NewEngine(Option.Engine().HTTPCodeMapper(HTTPCodeMap{
+		40012: http.StatusBadRequest,
+		20101: http.StatusCreated,
+	})))
+
+	if got := m.Bail("foo").Code(40012).Freeze().HTTPCode(); got != http.StatusBadRequest {
+		t.Errorf("mapped error HTTPCode() = %v, want %v", got, http.StatusBadRequest)
+	}
+	if got := m.Bail("foo").Code(7404).Freeze().HTTPCode(); got != http.StatusNotFound {
+		t.Errorf("unmapped error HTTPCode() = %v, want %v", got, http.StatusNotFound)
+	}
+	if got := m.Bail("foo").Code(40012).HTTPCode(http.StatusConflict).Freeze().HTTPCode(); got != http.StatusConflict {
+		t.Errorf("explicit error HTTPCode() = %v, want %v", got, http.StatusConflict)
+	}
+	if got := m.NewEntry("foo").Code(20101).Freeze().HTTPCode(); got != http.StatusCreated {
+		t.Errorf("mapped entry HTTPCode() = %v, want %v", got, http.StatusCreated)
+	}
+}
@@ -1,5 +1,11 @@
 package maleo
 
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
 // Level represents the level severity of the message.
 type Level int8
 
@@ -30,3 +36,81 @@ func (l Level) String() string {
 		return "unknown"
 	}
 }
+
+// Enabled implements LevelEnabler. Returns true if given lvl is at least as severe as this level.
+func (l Level) Enabled(lvl Level) bool {
+	return lvl >= l
+}
+
+// LevelEnabler decides whether a given level is enabled.
+//
+// Level and AtomicLevel implement this interface.
+type LevelEnabler interface {
+	// Enabled returns true if given level is enabled.
+	Enabled(lvl Level) bool
+}
+
+var (
+	_ LevelEnabler = Level(0)
+	_ LevelEnabler = AtomicLevel{}
+)
+
+// AtomicLevel is a handle to a minimum Level that can be changed at runtime safely.
+//
+// Copies of AtomicLevel share the same underlying value, so changing the level from one copy is visible to all.
+//
+// Example:
+//
+//	level := maleo.NewAtomicLevel(maleo.InfoLevel)
+//	m := maleo.New(service, maleo.Option.Init().LogLevel(level))
+//	// later, turn on debug logging in a live process.
+//	level.SetLevel(maleo.DebugLevel)
+type AtomicLevel struct {
+	lvl *atomic.Int32
+}
+
+// NewAtomicLevel creates a new AtomicLevel with the given initial level.
+func NewAtomicLevel(lvl Level) AtomicLevel {
+	a := AtomicLevel{lvl: new(atomic.Int32)}
+	a.lvl.Store(int32(lvl))
+	return a
+}
+
+// Level returns the current minimum level.
+func (a AtomicLevel) Level() Level {
+	return Level(a.lvl.Load())
+}
+
+// SetLevel changes the minimum level.
+func (a AtomicLevel) SetLevel(lvl Level) {
+	a.lvl.Store(int32(lvl))
+}
+
+// Enabled implements LevelEnabler.
+func (a AtomicLevel) Enabled(lvl Level) bool {
+	return a.Level().Enabled(lvl)
+}
+
+// String returns the string representation of the current minimum level.
+func (a AtomicLevel) String() string {
+	return a.Level().String()
+}
+
+// ParseLevel parses the level from its string representation, e.g. "error". Parsing is case-insensitive.
+func ParseLevel(s string) (Level, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "debug":
+		return DebugLevel, nil
+	case "info":
+		return InfoLevel, nil
+	case "warn", "warning":
+		return WarnLevel, nil
+	case "error":
+		return ErrorLevel, nil
+	case "fatal":
+		return FatalLevel, nil
+	case "panic":
+		return PanicLevel, nil
+	}
+	return 0, fmt.Errorf("maleo: unknown level %q", s)
+}
@@ -52,3 +52,21 @@ func TestLevel_String(t *testing.T) {
 		})
 	}
 }
+
+func TestAtomicLevel(t *testing.T) {
+	lvl := NewAtomicLevel(InfoLevel)
+	if lvl.Enabled(DebugLevel) {
+		t.Error("DebugLevel should not be enabled on InfoLevel")
+	}
+	if !lvl.Enabled(ErrorLevel) {
+		t.Error("ErrorLevel should be enabled on InfoLevel")
+	}
+	cp := lvl
+	cp.SetLevel(DebugLevel)
+	if !lvl.Enabled(DebugLevel) {
+		t.Error("DebugLevel should be enabled after SetLevel on a copy")
+	}
+	if lvl.String() != "debug" {
+		t.Errorf("String() = %v, want %v", lvl.String(), "debug")
+	}
+}
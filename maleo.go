@@ -11,13 +11,25 @@ import (
 )
 
 type Maleo struct {
-	service       Service
-	defaultParams *MessageParameters
-	logger        Logger
-	engine        Engine
-	callerDepth   int
-	name          string
-	isGlobal      bool
+	service          Service
+	defaultParams    *MessageParameters
+	logger           Logger
+	logLevel         LevelEnabler
+	notifyLevel      LevelEnabler
+	messengerLevels  map[string]LevelEnabler
+	router           *Router
+	escalation       *Escalation
+	engine           Engine
+	callerDepth      int
+	stackLevel       LevelEnabler
+	stackFilter      FrameFilter
+	stackDepth       int
+	fields           Fields
+	component        string
+	name             string
+	isGlobal         bool
+	exit             func(code int)
+	terminateTimeout time.Duration
 }
 
 // New creates a new Maleo instance.
@@ -30,9 +42,16 @@ func New(service Service, opts ...InitOption) *Maleo {
 			Cooldown:   time.Minute * 15,
 			ForceSend:  false,
 		},
-		engine:      NewEngine(),
-		logger:      NoopLogger{},
-		callerDepth: 2,
+		engine:           NewEngine(),
+		logger:           NoopLogger{},
+		logLevel:         DebugLevel,
+		notifyLevel:      DebugLevel,
+		messengerLevels:  map[string]LevelEnabler{},
+		callerDepth:      2,
+		stackFilter:      DefaultFrameFilter,
+		stackDepth:       32,
+		exit:             defaultExit,
+		terminateTimeout: defaultTerminateTimeout,
 	}
 	m.defaultParams.Maleo = m
 	for _, opt := range opts {
@@ -52,9 +71,13 @@ func (m *Maleo) Name() string {
 // SendMessage implements the Messenger interface. Maleo instance itself can be a Messenger for other Maleo instances.
 //
 // Note that SendMessage for Maleo only support Messengers that are set in initialization or call Maleo.Register.
+//
+// Messengers whose minimum level is above the message's level are skipped.
 func (m *Maleo) SendMessage(ctx context.Context, msg MessageContext) {
 	for _, v := range m.defaultParams.Messengers {
-		v.SendMessage(ctx, msg)
+		if m.messengerEnabled(v, msg.Level()) {
+			v.SendMessage(ctx, msg)
+		}
 	}
 }
 
@@ -116,10 +139,11 @@ func (m *Maleo) Wrap(err error, msgAndArgs ...any) ErrorBuilder {
 		err = ErrNil
 	}
 	caller := GetCaller(m.callerDepth)
-	return m.engine.ConstructError(&ErrorConstructorContext{
+	return m.constructError(&ErrorConstructorContext{
 		Err:            err,
 		Caller:         caller,
 		Maleo:          m,
+		Stack:          m.captureStack(),
 		MessageAndArgs: msgAndArgs,
 	})
 }
@@ -130,10 +154,11 @@ func (m *Maleo) WrapFreeze(err error, msgAndArgs ...any) Error {
 		err = ErrNil
 	}
 	caller := GetCaller(m.callerDepth)
-	return m.engine.ConstructError(&ErrorConstructorContext{
+	return m.constructError(&ErrorConstructorContext{
 		Err:            err,
 		Caller:         caller,
 		Maleo:          m,
+		Stack:          m.captureStack(),
 		MessageAndArgs: msgAndArgs,
 	}).Freeze()
 }
@@ -144,7 +169,7 @@ func (m *Maleo) NewEntry(msg string, args ...any) EntryBuilder {
 		msg = fmt.Sprintf(msg, args...)
 	}
 	caller := GetCaller(m.callerDepth)
-	return m.engine.ConstructEntry(&EntryConstructorContext{
+	return m.constructEntry(&EntryConstructorContext{
 		Caller:  caller,
 		Maleo:   m,
 		Message: msg,
@@ -163,10 +188,11 @@ func (m *Maleo) Bail(msg string, args ...any) ErrorBuilder {
 		err = errors.New(msg)
 	}
 	caller := GetCaller(m.callerDepth)
-	return m.engine.ConstructError(&ErrorConstructorContext{
+	return m.constructError(&ErrorConstructorContext{
 		Err:    err,
 		Caller: caller,
 		Maleo:  m,
+		Stack:  m.captureStack(),
 	})
 }
 
@@ -182,44 +208,121 @@ func (m *Maleo) BailFreeze(msg string, args ...any) Error {
 		err = errors.New(msg)
 	}
 	caller := GetCaller(m.callerDepth)
-	return m.engine.ConstructError(&ErrorConstructorContext{
+	return m.constructError(&ErrorConstructorContext{
 		Err:    err,
 		Caller: caller,
 		Maleo:  m,
+		Stack:  m.captureStack(),
 	}).Freeze()
 }
 
-// Notify Sends the Entry to Messengers.
+// Notify Sends the Entry to Messengers. Entries and messages dropped by the Hooks of the Engine are not sent.
 func (m *Maleo) Notify(ctx context.Context, entry Entry, parameters ...MessageOption) {
 	opts := m.defaultParams.clone()
 	for _, v := range parameters {
 		v.Apply(opts)
 	}
-	msg := m.engine.BuildEntryMessageContext(entry, opts)
+	msg := m.buildEntryMessage(entryWithContextFields(ctx, entry), opts)
+	if msg == nil {
+		return
+	}
+	m.route(msg, opts)
+	msg = m.escalate(ctx, msg, opts)
 	m.sendNotif(ctx, msg, opts)
 }
 
-// NotifyError sends the Error to Messengers.
+// NotifyError sends the Error to Messengers. Errors and messages dropped by the Hooks of the Engine are not sent.
 func (m *Maleo) NotifyError(ctx context.Context, err Error, parameters ...MessageOption) {
 	opts := m.defaultParams.clone()
 	for _, v := range parameters {
 		v.Apply(opts)
 	}
-	msg := m.engine.BuildErrorMessageContext(err, opts)
+	msg := m.buildErrorMessage(ctx, err, opts)
+	if msg == nil {
+		return
+	}
+	m.route(msg, opts)
+	msg = m.escalate(ctx, msg, opts)
 	m.sendNotif(ctx, msg, opts)
 }
 
+// ExplainNotify shows which routing rules match the Entry and which Messengers would receive it, without sending it.
+// Use it to debug the rules set by Option.Init().Router.
+func (m *Maleo) ExplainNotify(ctx context.Context, entry Entry, parameters ...MessageOption) RouteExplanation {
+	opts := m.defaultParams.clone()
+	for _, v := range parameters {
+		v.Apply(opts)
+	}
+	msg := m.buildEntryMessage(entryWithContextFields(ctx, entry), opts)
+	if msg == nil {
+		return RouteExplanation{Messengers: []string{}, Dropped: true}
+	}
+	return m.explain(msg, opts)
+}
+
+// ExplainNotifyError shows which routing rules match the Error and which Messengers would receive it,
+// without sending it. Use it to debug the rules set by Option.Init().Router.
+func (m *Maleo) ExplainNotifyError(ctx context.Context, err Error, parameters ...MessageOption) RouteExplanation {
+	opts := m.defaultParams.clone()
+	for _, v := range parameters {
+		v.Apply(opts)
+	}
+	msg := m.buildErrorMessage(ctx, err, opts)
+	if msg == nil {
+		return RouteExplanation{Messengers: []string{}, Dropped: true}
+	}
+	return m.explain(msg, opts)
+}
+
+func (m *Maleo) route(msg MessageContext, opts *MessageParameters) []RouteRuleResult {
+	if m.router == nil {
+		return nil
+	}
+	return m.router.route(msg, opts)
+}
+
+func (m *Maleo) explain(msg MessageContext, opts *MessageParameters) RouteExplanation {
+	explanation := RouteExplanation{
+		Rules:      m.route(msg, opts),
+		Messengers: []string{},
+	}
+	for _, v := range opts.Messengers {
+		if m.messengerEnabled(v, msg.Level()) {
+			explanation.Messengers = append(explanation.Messengers, v.Name())
+		} else {
+			explanation.Disabled = append(explanation.Disabled, v.Name())
+		}
+	}
+	return explanation
+}
+
 func (m *Maleo) sendNotif(ctx context.Context, msg MessageContext, opts *MessageParameters) {
 	ctx = DetachedContext(ctx)
 	for _, v := range opts.Messengers {
-		v.SendMessage(ctx, msg)
+		if m.messengerEnabled(v, msg.Level()) {
+			v.SendMessage(ctx, msg)
+		}
 	}
 }
 
+// messengerEnabled checks the minimum level set specifically for the Messenger, and falls back to the minimum
+// level for all Messengers if there is none.
+func (m *Maleo) messengerEnabled(messenger Messenger, lvl Level) bool {
+	if enabler, ok := m.messengerLevels[messenger.Name()]; ok {
+		return enabler.Enabled(lvl)
+	}
+	return m.notifyLevel.Enabled(lvl)
+}
+
 func (m *Maleo) SetLogger(logger Logger) {
 	m.logger = logger
 }
 
+// SetRouter sets the Router that picks the Messengers of the messages. Pass nil to disable routing.
+func (m *Maleo) SetRouter(router *Router) {
+	m.router = router
+}
+
 func (m *Maleo) SetEngine(engine Engine) {
 	m.engine = engine
 }
@@ -228,20 +331,109 @@ func (m *Maleo) SetEngine(engine Engine) {
 //
 // The new Maleo instance will have the same name, logger, engine, and caller depth, but marked as non-global.
 func (m *Maleo) Clone() *Maleo {
-	return &Maleo{
-		service:       m.service,
-		defaultParams: m.defaultParams.clone(),
-		logger:        m.logger,
-		engine:        m.engine,
-		callerDepth:   m.callerDepth,
-		name:          m.name,
-		isGlobal:      false,
+	messengerLevels := make(map[string]LevelEnabler, len(m.messengerLevels))
+	for k, v := range m.messengerLevels {
+		messengerLevels[k] = v
+	}
+	clone := &Maleo{
+		service:          m.service,
+		defaultParams:    m.defaultParams.clone(),
+		logger:           m.logger,
+		logLevel:         m.logLevel,
+		notifyLevel:      m.notifyLevel,
+		messengerLevels:  messengerLevels,
+		router:           m.router,
+		escalation:       m.escalation,
+		engine:           m.engine,
+		callerDepth:      m.callerDepth,
+		stackLevel:       m.stackLevel,
+		stackFilter:      m.stackFilter,
+		stackDepth:       m.stackDepth,
+		fields:           m.fields,
+		component:        m.component,
+		name:             m.name,
+		isGlobal:         false,
+		exit:             m.exit,
+		terminateTimeout: m.terminateTimeout,
+	}
+	clone.defaultParams.Maleo = clone
+	return clone
+}
+
+// With creates a child Maleo instance that inherits everything from the current instance.
+//
+// Every Entry and Error built by the child instance carries the given fields, merged with the fields inherited
+// from the current instance, where the given fields take precedence. The fields are rendered alongside the fields
+// from maleo.ContextWithFields.
+func (m *Maleo) With(fields Fields) *Maleo {
+	child := m.child()
+	child.fields = mergeFields(m.fields, fields)
+	return child
+}
+
+// Named creates a child Maleo instance that inherits everything from the current instance,
+// with the component name set for every Entry and Error built by the child instance.
+//
+// Calling Named on a named instance joins the component names with a dot. e.g. m.Named("db").Named("postgres")
+// results in component name "db.postgres".
+//
+// Messengers may use the component to route messages or to keep separate cooldown keys per component.
+func (m *Maleo) Named(component string) *Maleo {
+	child := m.child()
+	if m.component != "" {
+		component = m.component + "." + component
+	}
+	child.component = component
+	return child
+}
+
+func (m *Maleo) child() *Maleo {
+	child := m.Clone()
+	if m.isGlobal {
+		// Global instance accounts for the exported package level functions, but the child is called directly.
+		child.callerDepth--
+	}
+	return child
+}
+
+// Fields returns the fields inherited by the Entry and Error built by this instance. See Maleo.With.
+//
+// The returned Fields must not be modified.
+func (m *Maleo) Fields() Fields {
+	if m == nil {
+		return nil
+	}
+	return m.fields
+}
+
+// Component returns the component name of this instance. See Maleo.Named.
+func (m *Maleo) Component() string {
+	if m == nil {
+		return ""
+	}
+	return m.component
+}
+
+// captureStack captures the stack trace for Error constructors, if enabled. Must be called directly by the constructors,
+// at the same depth as GetCaller.
+func (m *Maleo) captureStack() []uintptr {
+	if m.stackLevel == nil {
+		return nil
 	}
+	return capturePCs(m.callerDepth+1, m.stackDepth)
 }
 
 // Log implements the Logger interface. Maleo instance itself can be a Logger for other Maleo instance.
 //
 // If ctx contains a Maleo instance, and current instance is a Global one, it will be used instead.
+//
+// Entries below the minimum log level, or dropped by the Hooks of the Engine, are discarded. Fields attached to ctx by
+// ContextWithFields are added to the entry.
+//
+// Entries with FatalLevel or PanicLevel terminate the program after logging. The entry is sent to every Messenger,
+// including the benched ones, with ForceSend. Then the Messengers are waited, up to the timeout set by
+// Option.Init().TerminateTimeout, and finally the program exits with code 1 for FatalLevel, or panics for PanicLevel.
+// Contexts created by ContextWithoutTermination only log the entry. Discarded entries do not terminate the program.
 func (m *Maleo) Log(ctx context.Context, entry Entry) {
 	if m.isGlobal {
 		if mctx := MaleoFromContext(ctx); mctx != nil {
@@ -249,12 +441,25 @@ func (m *Maleo) Log(ctx context.Context, entry Entry) {
 			return
 		}
 	}
-	m.logger.Log(ctx, entry)
+	if !m.logLevel.Enabled(entry.Level()) || isDropped(entry) {
+		return
+	}
+	m.logger.Log(ctx, entryWithContextFields(ctx, entry))
+	if terminates(entry.Level()) && !terminationDisabled(ctx) {
+		m.terminateEntry(ctx, entry)
+	}
 }
 
 // LogError implements the Logger interface. Maleo instance itself can be a Logger for other Maleo instance.
 //
 // If ctx contains a Maleo instance, and current instance is a Global one, it will be used instead.
+//
+// Errors below the minimum log level, or dropped by the Hooks of the Engine, are discarded. Fields attached to ctx by
+// ContextWithFields are added to the error.
+//
+// Errors with FatalLevel or PanicLevel terminate the program after logging, like Log does, with the Error as the
+// panic value. Errors created from recovered panics, e.g. by Recover, logged with contexts created by
+// ContextWithoutTermination, or discarded, do not terminate the program.
 func (m *Maleo) LogError(ctx context.Context, err Error) {
 	if m.isGlobal {
 		if mctx := MaleoFromContext(ctx); mctx != nil {
@@ -262,7 +467,13 @@ func (m *Maleo) LogError(ctx context.Context, err Error) {
 			return
 		}
 	}
-	m.logger.LogError(ctx, err)
+	if !m.logLevel.Enabled(err.Level()) || isDropped(err) {
+		return
+	}
+	m.logger.LogError(ctx, errorWithContextFields(ctx, err))
+	if terminates(err.Level()) && !recoveredPanic(err) && !terminationDisabled(ctx) {
+		m.terminateError(ctx, err)
+	}
 }
 
 func (m *Maleo) Service() Service {
@@ -1,5 +1,7 @@
 package maleo
 
+import "time"
+
 type InitOption interface {
 	apply(*Maleo)
 }
@@ -56,3 +58,98 @@ func (i InitOptionBuilder) CallerDepth(depth int) InitOptionBuilder {
 		m.callerDepth = depth
 	}))
 }
+
+// LogLevel sets the minimum level of Entry and Error to be passed to the Logger. Defaults to DebugLevel,
+// which means everything is logged.
+//
+// Use maleo.NewAtomicLevel to create a level that can be changed at runtime.
+func (i InitOptionBuilder) LogLevel(lvl LevelEnabler) InitOptionBuilder {
+	return append(i, InitOptionFunc(func(m *Maleo) {
+		m.logLevel = lvl
+	}))
+}
+
+// NotifyLevel sets the minimum level of messages to be sent to the Messengers. Defaults to DebugLevel,
+// which means every message is sent.
+//
+// This applies to all Messengers, except those that have their own minimum level set by MessengerLevel.
+//
+// Use maleo.NewAtomicLevel to create a level that can be changed at runtime.
+func (i InitOptionBuilder) NotifyLevel(lvl LevelEnabler) InitOptionBuilder {
+	return append(i, InitOptionFunc(func(m *Maleo) {
+		m.notifyLevel = lvl
+	}))
+}
+
+// MessengerLevel sets the minimum level of messages to be sent to the Messenger with the given name.
+// This overrides the level set by NotifyLevel for that Messenger.
+//
+// Use maleo.NewAtomicLevel to create a level that can be changed at runtime.
+func (i InitOptionBuilder) MessengerLevel(name string, lvl LevelEnabler) InitOptionBuilder {
+	return append(i, InitOptionFunc(func(m *Maleo) {
+		m.messengerLevels[name] = lvl
+	}))
+}
+
+// Router sets the Router that picks the Messengers of the messages with routing rules.
+// The rules are applied after the message options of each Notify call.
+//
+// Use RouteConfig.Router to create the Router from a configuration file.
+func (i InitOptionBuilder) Router(router *Router) InitOptionBuilder {
+	return append(i, InitOptionFunc(func(m *Maleo) {
+		m.router = router
+	}))
+}
+
+// Escalation sets the Escalation that sends repeated messages to more Messengers with raised levels.
+// Escalation is applied after the routing rules.
+func (i InitOptionBuilder) Escalation(escalation *Escalation) InitOptionBuilder {
+	return append(i, InitOptionFunc(func(m *Maleo) {
+		m.escalation = escalation
+	}))
+}
+
+// StackTrace enables capturing multi-frame stack traces for Errors created by Wrap and Bail.
+// The stack trace is available from the Error through the StackHint interface.
+//
+// The stack trace is always captured on creation, but is only reported for Errors with level enabled by lvl.
+// Pass nil to disable stack trace capture, which is the default.
+func (i InitOptionBuilder) StackTrace(lvl LevelEnabler) InitOptionBuilder {
+	return append(i, InitOptionFunc(func(m *Maleo) {
+		m.stackLevel = lvl
+	}))
+}
+
+// StackTraceFilter sets the filter for the frames of the captured stack traces. Defaults to DefaultFrameFilter.
+//
+// Pass nil to keep every frame.
+func (i InitOptionBuilder) StackTraceFilter(filter FrameFilter) InitOptionBuilder {
+	return append(i, InitOptionFunc(func(m *Maleo) {
+		m.stackFilter = filter
+	}))
+}
+
+// StackTraceDepth sets the maximum number of frames captured for stack traces, before the frames are filtered.
+// Defaults to 32.
+func (i InitOptionBuilder) StackTraceDepth(depth int) InitOptionBuilder {
+	return append(i, InitOptionFunc(func(m *Maleo) {
+		m.stackDepth = depth
+	}))
+}
+
+// ExitFunc sets the function that exits the program after logging with FatalLevel. Defaults to os.Exit.
+//
+// Useful in tests to assert Fatal logs without exiting the test binary.
+func (i InitOptionBuilder) ExitFunc(exit func(code int)) InitOptionBuilder {
+	return append(i, InitOptionFunc(func(m *Maleo) {
+		m.exit = exit
+	}))
+}
+
+// TerminateTimeout sets how long the Messengers are waited for after logging with FatalLevel or PanicLevel, before
+// the program exits or panics. Defaults to 10 seconds.
+func (i InitOptionBuilder) TerminateTimeout(d time.Duration) InitOptionBuilder {
+	return append(i, InitOptionFunc(func(m *Maleo) {
+		m.terminateTimeout = d
+	}))
+}
@@ -2,7 +2,9 @@ package maleo
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"reflect"
 	"testing"
 )
 
@@ -77,3 +79,114 @@ func TestNewMaleo(t *testing.T) {
 		t.Errorf("Service() = %v, want %v", mal.Service(), Service{})
 	}
 }
+
+type countingMessenger struct {
+	name  string
+	count int
+}
+
+func (c *countingMessenger) Name() string                                { return c.name }
+func (c *countingMessenger) SendMessage(context.Context, MessageContext) { c.count++ }
+func (c *countingMessenger) Wait(context.Context) error                  { return nil }
+
+func TestMaleo_LevelFiltering(t *testing.T) {
+	logLevel := NewAtomicLevel(InfoLevel)
+	all := &countingMessenger{name: "all"}
+	errOnly := &countingMessenger{name: "error-only"}
+	logger := &countingLogger{}
+	mal := New(Service{}, Option.Init().
+		Logger(logger).
+		LogLevel(logLevel).
+		NotifyLevel(DebugLevel).
+		MessengerLevel("error-only", ErrorLevel).
+		Messengers(all, errOnly),
+	)
+	ctx := context.Background()
+
+	mal.NewEntry("debug").Level(DebugLevel).Log(ctx).Notify(ctx)
+	mal.NewEntry("info").Log(ctx).Notify(ctx)
+	_ = mal.Bail("error").Log(ctx).Notify(ctx)
+
+	if logger.count != 2 {
+		t.Errorf("logged = %d, want %d", logger.count, 2)
+	}
+	if all.count != 3 {
+		t.Errorf("messenger 'all' received = %d, want %d", all.count, 3)
+	}
+	if errOnly.count != 1 {
+		t.Errorf("messenger 'error-only' received = %d, want %d", errOnly.count, 1)
+	}
+
+	logLevel.SetLevel(DebugLevel)
+	mal.NewEntry("debug").Level(DebugLevel).Log(ctx)
+	if logger.count != 3 {
+		t.Errorf("logged after level change = %d, want %d", logger.count, 3)
+	}
+
+	clone := mal.Clone()
+	clone.NewEntry("info").Notify(ctx)
+	if errOnly.count != 1 {
+		t.Errorf("cloned instance should keep messenger levels, 'error-only' received = %d, want %d", errOnly.count, 1)
+	}
+}
+
+type countingLogger struct {
+	count int
+}
+
+func (c *countingLogger) Log(context.Context, Entry)      { c.count++ }
+func (c *countingLogger) LogError(context.Context, Error) { c.count++ }
+
+func TestMaleo_WithNamed(t *testing.T) {
+	parent, logger := NewTestingMaleo()
+	messenger := &recordingMessenger{}
+	parent.Register(messenger)
+	child := parent.With(F{"tenant": "foo", "shard": 1}).Named("db").With(F{"shard": 2}).Named("postgres")
+
+	if child.Component() != "db.postgres" {
+		t.Errorf("Component() = %v, want %v", child.Component(), "db.postgres")
+	}
+	if parent.Component() != "" || parent.Fields() != nil {
+		t.Errorf("parent should not be modified, got component %q and fields %v", parent.Component(), parent.Fields())
+	}
+
+	ctx := ContextWithFields(context.Background(), F{"request_id": "abc"})
+	err := child.Bail("boom").Log(ctx).Notify(ctx)
+	if got := err.Caller().ShortName(); got != "maleo.TestMaleo_WithNamed" {
+		t.Errorf("Caller() = %v, want %v", got, "maleo.TestMaleo_WithNamed")
+	}
+
+	var out struct {
+		Component     string `json:"component"`
+		ContextFields F      `json:"context_fields"`
+	}
+	if err := json.Unmarshal(logger.Bytes(), &out); err != nil {
+		t.Fatalf("failed to unmarshal log output: %v: %s", err, logger.String())
+	}
+	if out.Component != "db.postgres" {
+		t.Errorf("logged component = %v, want %v", out.Component, "db.postgres")
+	}
+	want := F{"tenant": "foo", "shard": float64(2), "request_id": "abc"}
+	if !reflect.DeepEqual(out.ContextFields, want) {
+		t.Errorf("logged context_fields = %v, want %v", out.ContextFields, want)
+	}
+
+	if len(messenger.messages) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(messenger.messages))
+	}
+	msg := messenger.messages[0]
+	if msg.Maleo() != child {
+		t.Errorf("expected message to be sent by the child instance")
+	}
+	if ch, ok := msg.(ComponentHint); !ok || ch.Component() != "db.postgres" {
+		t.Errorf("expected message to carry the component name")
+	}
+	if cf, ok := msg.(ContextFieldsHint); !ok || cf.ContextFields()["tenant"] != "foo" {
+		t.Errorf("expected message to carry the inherited fields")
+	}
+
+	global := Global().Named("global-child")
+	if got := global.BailFreeze("boom").Caller().ShortName(); got != "maleo.TestMaleo_WithNamed" {
+		t.Errorf("child of global Caller() = %v, want %v", got, "maleo.TestMaleo_WithNamed")
+	}
+}
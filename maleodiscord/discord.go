@@ -15,6 +15,7 @@ import (
 	"github.com/tigorlazuardi/maleo/bucket"
 	"github.com/tigorlazuardi/maleo/locker"
 	"github.com/tigorlazuardi/maleo/queue"
+	"github.com/tigorlazuardi/maleo/spool"
 )
 
 func init() {
@@ -39,6 +40,11 @@ type Discord struct {
 	dataEncoder      DataEncoder
 	codeBlockBuilder CodeBlockBuilder
 	outgoing         *sync.WaitGroup
+	spool            *spool.Spool
+	spoolRetry       time.Duration
+	spoolMu          sync.Mutex
+	inflight         map[uint64]struct{}
+	retryScheduled   int32
 }
 
 // Name implements maleo.Messenger interface.
@@ -51,10 +57,38 @@ func (d *Discord) Name() string {
 
 // SendMessage implements maleo.Messenger interface.
 func (d *Discord) SendMessage(ctx context.Context, msg maleo.MessageContext) {
-	d.queue.Enqueue(NewJob(ctx, msg))
+	job := NewJob(ctx, msg)
+	if d.spool != nil {
+		id, err := d.spool.Append(msg)
+		if err != nil {
+			_ = msg.Maleo().Wrap(err).Caller(msg.Caller()).Message("%s: failed to spool message", d.Name()).Log(ctx)
+		}
+		job.SpoolID = id
+	}
+	if job.SpoolID == 0 {
+		d.queue.Enqueue(job)
+	} else if !d.enqueueSpooled(job) {
+		d.scheduleRedelivery()
+	}
 	d.work()
 }
 
+// Deliver implements maleo.DeliveryMessenger interface. It posts the message and returns after the post is done, with
+// the error of the post, so maleo.Middleware.Retry can retry failed posts.
+//
+// Delivered messages skip the queue and the spool: the caller owns the retries. Wait still waits for them.
+func (d *Discord) Deliver(ctx context.Context, msg maleo.MessageContext) error {
+	d.outgoing.Add(1)
+	defer d.outgoing.Done()
+	select {
+	case d.sem <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() { <-d.sem }()
+	return d.send(ctx, msg)
+}
+
 // Wait implements maleo.Messenger interface.
 func (d *Discord) Wait(ctx context.Context) error {
 	sig := make(chan struct{})
@@ -81,7 +115,11 @@ func (d *Discord) work() {
 				kv := d.queue.Dequeue()
 				go func() {
 					ctx := maleo.DetachedContext(kv.Context)
-					d.send(ctx, kv.Message)
+					err := d.send(ctx, kv.Message)
+					if err == nil {
+						d.markDone(ctx, kv)
+					}
+					d.releaseSpooled(kv, err)
 					<-d.sem
 					d.outgoing.Done()
 				}()
@@ -92,6 +130,90 @@ func (d *Discord) work() {
 	}
 }
 
+func (d *Discord) markDone(ctx context.Context, job *Job) {
+	if d.spool == nil || job.SpoolID == 0 {
+		return
+	}
+	if err := d.spool.Done(job.SpoolID); err != nil {
+		_ = job.Message.Maleo().Wrap(err).Caller(job.Message.Caller()).
+			Message("%s: failed to mark spooled message done", d.Name()).Log(ctx)
+	}
+}
+
+// replay sends the messages left in the spool by a previous run.
+func (d *Discord) replay() {
+	if d.spool == nil {
+		return
+	}
+	d.redeliver()
+}
+
+// enqueueSpooled puts the spooled job in the queue, unless the queue is full. Jobs are only enqueued under spoolMu, so
+// the queue cannot fill up between the check and Enqueue, and a job that is not enqueued is not marked in flight.
+func (d *Discord) enqueueSpooled(job *Job) bool {
+	d.spoolMu.Lock()
+	defer d.spoolMu.Unlock()
+	if d.queue.Len() >= d.queue.Cap() {
+		return false
+	}
+	if d.inflight == nil {
+		d.inflight = map[uint64]struct{}{}
+	}
+	d.inflight[job.SpoolID] = struct{}{}
+	d.queue.Enqueue(job)
+	return true
+}
+
+// releaseSpooled marks the spooled job not in flight anymore. Failed jobs stay in the spool, and are sent again later.
+func (d *Discord) releaseSpooled(job *Job, err error) {
+	if d.spool == nil || job.SpoolID == 0 {
+		return
+	}
+	d.spoolMu.Lock()
+	delete(d.inflight, job.SpoolID)
+	d.spoolMu.Unlock()
+	if err != nil {
+		d.scheduleRedelivery()
+	}
+}
+
+// redeliver enqueues the spooled messages that are not in flight. Messages that do not fit in the queue are tried
+// again later.
+func (d *Discord) redeliver() {
+	var enqueued, left bool
+	for _, record := range d.spool.Pending() {
+		d.spoolMu.Lock()
+		_, ok := d.inflight[record.ID]
+		d.spoolMu.Unlock()
+		if ok {
+			continue
+		}
+		job := &Job{Context: context.Background(), Message: record.MessageContext(nil), SpoolID: record.ID}
+		if d.enqueueSpooled(job) {
+			enqueued = true
+		} else {
+			left = true
+		}
+	}
+	if enqueued {
+		d.work()
+	}
+	if left {
+		d.scheduleRedelivery()
+	}
+}
+
+// scheduleRedelivery runs redeliver after the spool retry interval. Only one redelivery is scheduled at a time.
+func (d *Discord) scheduleRedelivery() {
+	if d.spoolRetry <= 0 || !atomic.CompareAndSwapInt32(&d.retryScheduled, 0, 1) {
+		return
+	}
+	time.AfterFunc(d.spoolRetry, func() {
+		atomic.StoreInt32(&d.retryScheduled, 0)
+		d.redeliver()
+	})
+}
+
 type Client interface {
 	Do(*http.Request) (*http.Response, error)
 }
@@ -99,6 +221,8 @@ type Client interface {
 type Job struct {
 	Context context.Context
 	Message maleo.MessageContext
+	// SpoolID is the id of the message in the spool. 0 if the message is not spooled.
+	SpoolID uint64
 }
 
 func NewJob(ctx context.Context, message maleo.MessageContext) *Job {
@@ -122,11 +246,13 @@ func NewDiscordBot(webhook string, opts ...DiscordOption) *Discord {
 		dataEncoder:      JSONDataEncoder{},
 		codeBlockBuilder: JSONCodeBlockBuilder{},
 		outgoing:         &sync.WaitGroup{},
+		spoolRetry:       time.Minute,
 	}
 	d.builder = EmbedBuilderFunc(d.defaultEmbedBuilder)
 	for _, opt := range opts {
 		opt.apply(d)
 	}
+	d.replay()
 	return d
 }
 
@@ -6,6 +6,7 @@ import (
 	"github.com/tigorlazuardi/maleo"
 	"github.com/tigorlazuardi/maleo/bucket"
 	"github.com/tigorlazuardi/maleo/locker"
+	"github.com/tigorlazuardi/maleo/spool"
 )
 
 type DiscordOption interface {
@@ -93,3 +94,20 @@ func WithClient(client Client) DiscordOption {
 		discord.client = client
 	})
 }
+
+// WithSpool keeps the messages on disk until they are posted to Discord. Messages left in the spool by a previous run
+// are sent again when the bot is created. Messages that fail to post, or do not fit in the queue, are sent again after
+// the interval set by WithSpoolRetry. Messages skipped by the cooldown are marked done as well.
+func WithSpool(s *spool.Spool) DiscordOption {
+	return discordOptionFunc(func(discord *Discord) {
+		discord.spool = s
+	})
+}
+
+// WithSpoolRetry sets the interval to send the spooled messages that failed to post, or did not fit in the queue,
+// again. Set to 0 to only send them again when the bot is created. Defaults to 1 minute.
+func WithSpoolRetry(d time.Duration) DiscordOption {
+	return discordOptionFunc(func(discord *Discord) {
+		discord.spoolRetry = d
+	})
+}
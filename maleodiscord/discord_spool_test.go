@@ -0,0 +1,116 @@
+package maleodiscord
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tigorlazuardi/maleo"
+	"github.com/tigorlazuardi/maleo/spool"
+)
+
+type statusClient struct {
+	server *httptest.Server
+}
+
+func (s statusClient) Do(req *http.Request) (*http.Response, error) {
+	u, err := url.Parse(s.server.URL)
+	if err != nil {
+		return nil, err
+	}
+	req.URL = u
+	return http.DefaultClient.Do(req)
+}
+
+func newStatusClient(t *testing.T, status int, count *int32) statusClient {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(count, 1)
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return statusClient{server: server}
+}
+
+func TestDiscordSpool(t *testing.T) {
+	dir := t.TempDir()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	sp, err := spool.Open(dir)
+	if err != nil {
+		t.Fatalf("failed to open spool: %v", err)
+	}
+	var failed int32
+	m, _ := maleo.NewTestingMaleo()
+	m.Register(NewDiscordBot("", WithClient(newStatusClient(t, http.StatusInternalServerError, &failed)), WithSpool(sp)))
+	m.NewEntry("undelivered").Notify(ctx)
+	if err := m.Wait(ctx); err != nil {
+		t.Fatalf("failed to wait: %v", err)
+	}
+	if atomic.LoadInt32(&failed) != 1 {
+		t.Fatalf("expected 1 request, got %d", failed)
+	}
+	if sp.Len() != 1 {
+		t.Fatalf("expected the undelivered message to stay in the spool, got %d", sp.Len())
+	}
+	_ = sp.Close()
+
+	sp, err = spool.Open(dir)
+	if err != nil {
+		t.Fatalf("failed to open spool: %v", err)
+	}
+	defer sp.Close()
+	var delivered int32
+	bot := NewDiscordBot("", WithClient(newStatusClient(t, http.StatusNoContent, &delivered)), WithSpool(sp))
+	if err := bot.Wait(ctx); err != nil {
+		t.Fatalf("failed to wait: %v", err)
+	}
+	if atomic.LoadInt32(&delivered) != 1 {
+		t.Fatalf("expected the spooled message to be sent again, got %d requests", delivered)
+	}
+	if sp.Len() != 0 {
+		t.Errorf("expected the delivered message to be marked done, got %d pending", sp.Len())
+	}
+}
+
+func TestDiscordSpool_Retry(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	sp, err := spool.Open(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to open spool: %v", err)
+	}
+	defer sp.Close()
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	m, _ := maleo.NewTestingMaleo()
+	m.Register(NewDiscordBot("",
+		WithClient(statusClient{server: server}),
+		WithSpool(sp),
+		WithSpoolRetry(10*time.Millisecond),
+	))
+	m.NewEntry("flaky").Notify(ctx)
+	for sp.Len() != 0 {
+		select {
+		case <-ctx.Done():
+			t.Fatalf("expected the failed message to be sent again, got %d requests", atomic.LoadInt32(&requests))
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	if got := atomic.LoadInt32(&requests); got != 2 {
+		t.Errorf("expected 2 requests, got %d", got)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -405,3 +406,59 @@ func TestDiscord(t *testing.T) {
 		})
 	}
 }
+
+func TestDiscord_DeliverRetry(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*15)
+	defer cancel()
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	m, logger := maleo.NewTestingMaleo()
+	bot := NewDiscordBot("", WithClient(statusClient{server: server}))
+	m.Register(maleo.Chain(bot, maleo.Middleware.Retry(3, maleo.ConstantBackoff(10*time.Millisecond))))
+	m.NewEntry("flaky").Notify(ctx)
+	if err := m.Wait(ctx); err != nil {
+		t.Fatalf("failed to wait: %v", err)
+	}
+	if got := atomic.LoadInt32(&requests); got != 2 {
+		t.Errorf("expected the failed post to be retried once, got %d requests", got)
+	}
+	if out := logger.String(); out != "" {
+		t.Errorf("expected nothing logged after a successful retry, got %s", out)
+	}
+}
+
+func TestDiscord_DigestAfterCooldown(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*15)
+	defer cancel()
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	m, _ := maleo.NewTestingMaleo()
+	bot := NewDiscordBot("", WithClient(statusClient{server: server}))
+	m.Register(maleo.Chain(bot, maleo.Middleware.Digest(
+		maleo.Option.Digest().Window(time.Hour).Bypass(maleo.ErrorLevel),
+	)))
+
+	// The error bypasses the digest and puts the key in cooldown.
+	_ = m.Bail("redis down").Key("cache").Notify(ctx)
+	_ = m.Bail("redis down").Key("cache").Level(maleo.WarnLevel).Notify(ctx)
+	_ = m.Bail("redis down").Key("cache").Level(maleo.WarnLevel).Notify(ctx)
+	if err := m.Wait(ctx); err != nil {
+		t.Fatalf("failed to wait: %v", err)
+	}
+	if got := atomic.LoadInt32(&requests); got != 2 {
+		t.Errorf("expected the error and the digest to be posted, got %d requests", got)
+	}
+}
@@ -3,7 +3,6 @@ package maleodiscord
 import (
 	"bytes"
 	"context"
-	"errors"
 	"fmt"
 	"strings"
 
@@ -247,25 +246,30 @@ func (d *Discord) buildErrorStackEmbed(
 }
 
 func stackAccumulator(s []string, err error) []string {
-	if err == nil {
-		return s
-	}
-	ss := &strings.Builder{}
-	chWritten := false
-	if ch, ok := err.(maleo.CallerHint); ok {
-		chWritten = true
+	maleo.Query.Walk(err, func(err error) bool {
+		ch, ok := err.(maleo.CallerHint) //nolint:errorlint
+		if !ok {
+			return true
+		}
+		ss := &strings.Builder{}
 		ss.WriteString(ch.Caller().String())
-	}
-	if chWritten {
-		if mh, ok := err.(maleo.MessageHint); ok {
+		if mh, ok := err.(maleo.MessageHint); ok { //nolint:errorlint
 			ss.WriteString(": ")
 			ss.WriteString(mh.Message())
 		}
-	}
-	if ss.Len() > 0 {
+		if sh, ok := err.(maleo.StackHint); ok { //nolint:errorlint
+			for _, frame := range sh.Frames() {
+				ss.WriteString("\n    at ")
+				ss.WriteString(frame.String())
+				ss.WriteString(" (")
+				ss.WriteString(frame.ShortName())
+				ss.WriteString(")")
+			}
+		}
 		s = append(s, ss.String())
-	}
-	return stackAccumulator(s, errors.Unwrap(err))
+		return true
+	})
+	return s
 }
 
 func reverse[S ~[]E, E any](s S) {
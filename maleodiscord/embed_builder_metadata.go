@@ -26,6 +26,11 @@ func (d *Discord) buildMetadataEmbed(
 	}
 	count = buildMetadataEmbedFields(ctx, msg, extra, d, embed, count)
 	display := buildMetadataBodyCaller(limit, msg, extra)
+	if cf, ok := msg.(maleo.ContextFieldsHint); ok {
+		buildMetadataBodyContextFields(display, cf.ContextFields())
+	} else {
+		buildMetadataBodyContextFields(display, maleo.FieldsFromContext(ctx))
+	}
 
 	embed, file, written := shouldCreateFile(&createFileContext{
 		embed:          embed,
@@ -60,10 +65,46 @@ func buildMetadataBodyCaller(limit int, msg maleo.MessageContext, extra *ExtraIn
 	return display
 }
 
+// buildMetadataBodyContextFields writes the fields from maleo.Maleo.With and maleo.ContextWithFields as their own section.
+func buildMetadataBodyContextFields(display *bytes.Buffer, fields maleo.Fields) {
+	if len(fields) == 0 {
+		return
+	}
+	display.WriteString("\n")
+	display.WriteString(`**Context Fields**`)
+	display.WriteString("\n```\n")
+	display.WriteString(fields.Summary())
+	display.WriteString("\n```")
+}
+
 func buildMetadataEmbedFields(ctx context.Context, msg maleo.MessageContext, extra *ExtraInformation, d *Discord, embed *Embed, count int) int {
 	count = buildTraceEmbedFields(ctx, d, embed, count)
 	service := msg.Service()
 	count = buildServiceEmbedFields(service, embed, count)
+	if ch, ok := msg.(maleo.ComponentHint); ok {
+		if component := ch.Component(); component != "" {
+			const componentName = "Component"
+			embed.Fields = append(embed.Fields, &EmbedField{
+				Name:   componentName,
+				Value:  component,
+				Inline: true,
+			})
+			count += len(componentName) + len(component)
+		}
+	}
+	if def, ok := maleo.FindDefinition(msg.Err()); ok {
+		const definitionName = "Definition"
+		value := def.Key()
+		if def.Retryable() {
+			value += " (retryable)"
+		}
+		embed.Fields = append(embed.Fields, &EmbedField{
+			Name:   definitionName,
+			Value:  value,
+			Inline: true,
+		})
+		count += len(definitionName) + len(value)
+	}
 	const threadIDName = "Thread ID"
 	embed.Fields = append(embed.Fields, &EmbedField{
 		Name:   threadIDName,
@@ -137,7 +178,34 @@ func buildServiceEmbedFields(service maleo.Service, embed *Embed, count int) int
 			Value:  service.Environment,
 			Inline: true,
 		})
-		count += len(env) + len(service.Type)
+		count += len(env) + len(service.Environment)
+	}
+	if service.Instance != "" {
+		const instance = "Instance"
+		embed.Fields = append(embed.Fields, &EmbedField{
+			Name:   instance,
+			Value:  service.Instance,
+			Inline: true,
+		})
+		count += len(instance) + len(service.Instance)
+	}
+	if service.Region != "" {
+		const region = "Region"
+		embed.Fields = append(embed.Fields, &EmbedField{
+			Name:   region,
+			Value:  service.Region,
+			Inline: true,
+		})
+		count += len(region) + len(service.Region)
+	}
+	if service.Commit != "" {
+		const commit = "Commit"
+		embed.Fields = append(embed.Fields, &EmbedField{
+			Name:   commit,
+			Value:  service.Commit,
+			Inline: true,
+		})
+		count += len(commit) + len(service.Commit)
 	}
 	return count
 }
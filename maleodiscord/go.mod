@@ -10,4 +10,5 @@ require (
 	github.com/tigorlazuardi/maleo/loader v0.5.0
 	github.com/tigorlazuardi/maleo/locker v0.5.0
 	github.com/tigorlazuardi/maleo/queue v0.5.0
+	github.com/tigorlazuardi/maleo/spool v0.5.0
 )
@@ -9,7 +9,8 @@ import (
 	"github.com/tigorlazuardi/maleo"
 )
 
-func (d *Discord) send(ctx context.Context, msg maleo.MessageContext) {
+// send posts the message to Discord, unless the message is still in cooldown. Returns the error of posting the message.
+func (d *Discord) send(ctx context.Context, msg maleo.MessageContext) error {
 	key := d.buildKey(msg)
 	ticker := time.NewTicker(time.Millisecond * 300)
 	for d.lock.Exist(ctx, d.globalKey) {
@@ -24,13 +25,13 @@ func (d *Discord) send(ctx context.Context, msg maleo.MessageContext) {
 	}
 	if msg.ForceSend() {
 		extra.CooldownTimeEnds = time.Now().Add(time.Second * 2)
-		_ = d.postMessage(ctx, msg, extra)
+		err := d.postMessage(ctx, msg, extra)
 		d.deleteGlobalCacheKeyAfter2Seconds(ctx)
-		return
+		return err
 	}
 	if d.lock.Exist(ctx, key) {
 		d.lock.Delete(ctx, d.globalKey)
-		return
+		return nil
 	}
 	defer d.deleteGlobalCacheKeyAfter2Seconds(ctx)
 	iterKey := key + d.lock.Separator() + "iter"
@@ -53,6 +54,7 @@ func (d *Discord) send(ctx context.Context, msg maleo.MessageContext) {
 				Log(ctx)
 		}
 	}
+	return err
 }
 
 func (d *Discord) deleteGlobalCacheKeyAfter2Seconds(ctx context.Context) {
@@ -148,12 +150,14 @@ func (d *Discord) buildKey(msg maleo.MessageContext) string {
 	builder.WriteString(d.lock.Separator())
 	builder.WriteString(service.Type)
 	builder.WriteString(d.lock.Separator())
-
-	key := msg.Key()
-	if key == "" {
-		key = msg.Caller().FormatAsKey()
+	if ch, ok := msg.(maleo.ComponentHint); ok {
+		if component := ch.Component(); component != "" {
+			builder.WriteString(component)
+			builder.WriteString(d.lock.Separator())
+		}
 	}
-	builder.WriteString(key)
+
+	builder.WriteString(msg.Maleo().MessageKey(msg))
 	return builder.String()
 }
 
@@ -0,0 +1,184 @@
+package maleogrpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"github.com/tigorlazuardi/maleo"
+)
+
+type ClientCallContext struct {
+	Context    context.Context
+	Target     string
+	FullMethod string
+	// Request is nil for stream calls.
+	Request any
+	// Response is nil for stream calls and failed calls.
+	Response any
+	// Stream is true if the call is a stream call.
+	Stream bool
+	Error  error
+	// Status is the status received from the server. Status is nil if the call succeeded.
+	Status   *status.Status
+	Duration time.Duration
+	Caller   maleo.Caller
+	Maleo    *maleo.Maleo
+}
+
+type clientInterceptor struct {
+	maleo       *maleo.Maleo
+	callerDepth int
+	filter      ClientFilterFunc
+	log         ClientLogFunc
+}
+
+func newClientInterceptor(opts []ClientOption) *clientInterceptor {
+	c := &clientInterceptor{}
+	opts = append(defaultClientOptions(), opts...)
+	for _, opt := range opts {
+		opt.apply(c)
+	}
+	return c
+}
+
+/*
+UnaryClientInterceptor creates a grpc.UnaryClientInterceptor that logs outbound calls with maleo engine, the same way
+maleohttp.RoundTrip does for HTTP requests.
+
+By default, the interceptor uses maleo's Global Instance.
+You may override this with maleogrpc.Option.Client().Maleo(*maleo.Maleo)
+
+Caller by default points to where the method of the generated client is called, but this assumes the interceptor is
+not chained with other interceptors. if the caller location is incorrect,
+You may override this with maleogrpc.Option.Client().AddCallerDepth(int) or maleogrpc.Option.Client().CallerDepth(int)
+
+For reference, the default caller depth is 4.
+
+Example:
+
+	conn, err := grpc.Dial(target,
+		grpc.WithUnaryInterceptor(maleogrpc.UnaryClientInterceptor()),
+		grpc.WithStreamInterceptor(maleogrpc.StreamClientInterceptor()),
+	)
+*/
+func UnaryClientInterceptor(opts ...ClientOption) grpc.UnaryClientInterceptor {
+	c := newClientInterceptor(opts)
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		caller := maleo.GetCaller(c.callerDepth)
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		call := &ClientCallContext{
+			Context:    ctx,
+			Target:     cc.Target(),
+			FullMethod: method,
+			Request:    req,
+			Error:      err,
+			Duration:   time.Since(start),
+			Caller:     caller,
+			Maleo:      c.maleo,
+		}
+		if err == nil {
+			call.Response = reply
+		}
+		c.finish(call)
+		return err
+	}
+}
+
+/*
+StreamClientInterceptor creates a grpc.StreamClientInterceptor that logs outbound stream calls with maleo engine.
+
+The call is logged when the stream fails to open, or when RecvMsg of the stream returns an error, which is io.EOF
+for streams that end successfully. Streams that are abandoned before that are not logged.
+
+Caller and options work the same way as UnaryClientInterceptor.
+*/
+func StreamClientInterceptor(opts ...ClientOption) grpc.StreamClientInterceptor {
+	c := newClientInterceptor(opts)
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		call := &ClientCallContext{
+			Context:    ctx,
+			Target:     cc.Target(),
+			FullMethod: method,
+			Stream:     true,
+			Caller:     maleo.GetCaller(c.callerDepth),
+			Maleo:      c.maleo,
+		}
+		start := time.Now()
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			call.Error = err
+			call.Duration = time.Since(start)
+			c.finish(call)
+			return nil, err
+		}
+		return &clientStream{ClientStream: stream, interceptor: c, call: call, start: start}, nil
+	}
+}
+
+func (c *clientInterceptor) finish(call *ClientCallContext) {
+	if call.Error != nil {
+		call.Status = status.Convert(call.Error)
+	}
+	if c.filter(call.Context, call.FullMethod) {
+		c.log(call)
+	}
+}
+
+type clientStream struct {
+	grpc.ClientStream
+	interceptor *clientInterceptor
+	call        *ClientCallContext
+	start       time.Time
+	once        sync.Once
+}
+
+func (c *clientStream) RecvMsg(m any) error {
+	err := c.ClientStream.RecvMsg(m)
+	if err != nil {
+		c.once.Do(func() {
+			if !errors.Is(err, io.EOF) {
+				c.call.Error = err
+			}
+			c.call.Duration = time.Since(c.start)
+			c.interceptor.finish(c.call)
+		})
+	}
+	return err
+}
+
+func defaultClientLogFunc(call *ClientCallContext) {
+	fields := maleo.F{
+		"target":   call.Target,
+		"method":   call.FullMethod,
+		"duration": call.Duration.String(),
+	}
+	if call.Request != nil {
+		fields["request"] = payload(call.Request)
+	}
+	if call.Response != nil {
+		fields["response"] = payload(call.Response)
+	}
+	if call.Status != nil {
+		fields["status"] = call.Status.Code().String()
+		// The error may be wrapped at FatalLevel or PanicLevel, e.g. by ErrorClassifiers, but must not stop the client.
+		_ = call.Maleo.Wrap(call.Error, "error: grpc %s", call.FullMethod).
+			Caller(call.Caller).
+			Code(HTTPFromCode(call.Status.Code())).
+			Context(fields).
+			Log(maleo.ContextWithoutTermination(call.Context))
+		return
+	}
+	fields["status"] = "OK"
+	call.Maleo.NewEntry("success: grpc %s", call.FullMethod).
+		Caller(call.Caller).
+		Code(200).
+		Context(fields).
+		Log(call.Context)
+}
@@ -0,0 +1,75 @@
+package maleogrpc
+
+import (
+	"context"
+
+	"github.com/tigorlazuardi/maleo"
+)
+
+type (
+	// ClientFilterFunc reports whether the call should be logged.
+	ClientFilterFunc = func(ctx context.Context, fullMethod string) bool
+	ClientLogFunc    = func(call *ClientCallContext)
+)
+
+type ClientOption interface {
+	apply(*clientInterceptor)
+}
+
+type (
+	ClientOptionFunc    func(*clientInterceptor)
+	ClientOptionBuilder []ClientOption
+)
+
+func (c ClientOptionFunc) apply(interceptor *clientInterceptor) {
+	c(interceptor)
+}
+
+func (c ClientOptionBuilder) apply(interceptor *clientInterceptor) {
+	for _, opt := range c {
+		opt.apply(interceptor)
+	}
+}
+
+// Maleo sets the maleo instance to use for logging.
+func (c ClientOptionBuilder) Maleo(m *maleo.Maleo) ClientOptionBuilder {
+	return append(c, ClientOptionFunc(func(interceptor *clientInterceptor) {
+		interceptor.maleo = m
+	}))
+}
+
+// CallerDepth sets the caller depth to the caller stack.
+func (c ClientOptionBuilder) CallerDepth(depth int) ClientOptionBuilder {
+	return append(c, ClientOptionFunc(func(interceptor *clientInterceptor) {
+		interceptor.callerDepth = depth
+	}))
+}
+
+// AddCallerDepth adds the caller depth to the caller stack.
+func (c ClientOptionBuilder) AddCallerDepth(depth int) ClientOptionBuilder {
+	return append(c, ClientOptionFunc(func(interceptor *clientInterceptor) {
+		interceptor.callerDepth += depth
+	}))
+}
+
+// Filter sets the filter of calls to log.
+func (c ClientOptionBuilder) Filter(filter ClientFilterFunc) ClientOptionBuilder {
+	return append(c, ClientOptionFunc(func(interceptor *clientInterceptor) {
+		interceptor.filter = filter
+	}))
+}
+
+// Log sets the function that logs the calls.
+func (c ClientOptionBuilder) Log(log ClientLogFunc) ClientOptionBuilder {
+	return append(c, ClientOptionFunc(func(interceptor *clientInterceptor) {
+		interceptor.log = log
+	}))
+}
+
+func defaultClientOptions() ClientOptionBuilder {
+	return Option.Client().
+		Maleo(maleo.Global()).
+		CallerDepth(4).
+		Filter(func(context.Context, string) bool { return true }).
+		Log(defaultClientLogFunc)
+}
@@ -0,0 +1,296 @@
+package maleogrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	health "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/tigorlazuardi/maleo"
+)
+
+type testHealthServer struct {
+	health.UnimplementedHealthServer
+	maleo *maleo.Maleo
+}
+
+func (t *testHealthServer) Check(_ context.Context, req *health.HealthCheckRequest) (*health.HealthCheckResponse, error) {
+	switch req.Service {
+	case "missing":
+		return nil, t.maleo.Bail("service %s not in registry", req.Service).Code(404).Key("health").PublicMessage("service not found").Context(maleo.F{"service": req.Service}).Freeze()
+	case "panic":
+		panic("boom")
+	case "fatal":
+		return nil, t.maleo.Bail("registry is gone").Code(503).Level(maleo.FatalLevel).Freeze()
+	}
+	return &health.HealthCheckResponse{Status: health.HealthCheckResponse_SERVING}, nil
+}
+
+func (t *testHealthServer) Watch(req *health.HealthCheckRequest, stream health.Health_WatchServer) error {
+	if req.Service == "missing" {
+		return t.maleo.Bail("service not found").Code(404).Freeze()
+	}
+	return stream.Send(&health.HealthCheckResponse{Status: health.HealthCheckResponse_SERVING})
+}
+
+type testLog struct {
+	Message string          `json:"message"`
+	Code    int             `json:"code"`
+	Level   string          `json:"level"`
+	Caller  json.RawMessage `json:"caller"`
+	Context json.RawMessage `json:"context"`
+}
+
+func decodeLogs(t *testing.T, logger *maleo.TestingJSONLogger) []testLog {
+	t.Helper()
+	var logs []testLog
+	dec := json.NewDecoder(bytes.NewReader(logger.Bytes()))
+	for {
+		var l testLog
+		err := dec.Decode(&l)
+		if errors.Is(err, io.EOF) {
+			return logs
+		}
+		if err != nil {
+			t.Fatalf("failed to decode log: %v", err)
+		}
+		logs = append(logs, l)
+	}
+}
+
+func newTestClient(t *testing.T) (client health.HealthClient, serverLogger, clientLogger *maleo.TestingJSONLogger) {
+	t.Helper()
+	serverMaleo, serverLogger := maleo.NewTestingMaleo()
+	clientMaleo, clientLogger := maleo.NewTestingMaleo()
+	return newTestClientWith(t, serverMaleo, clientMaleo), serverLogger, clientLogger
+}
+
+func newTestClientWith(t *testing.T, serverMaleo, clientMaleo *maleo.Maleo) health.HealthClient {
+	t.Helper()
+	lis := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(UnaryServerInterceptor(Option.Server().Maleo(serverMaleo))),
+		grpc.StreamInterceptor(StreamServerInterceptor(Option.Server().Maleo(serverMaleo))),
+	)
+	health.RegisterHealthServer(server, &testHealthServer{maleo: serverMaleo})
+	go func() {
+		_ = server.Serve(lis)
+	}()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(UnaryClientInterceptor(Option.Client().Maleo(clientMaleo))),
+		grpc.WithStreamInterceptor(StreamClientInterceptor(Option.Client().Maleo(clientMaleo))),
+	)
+	if err != nil {
+		t.Fatalf("failed to dial bufnet: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return health.NewHealthClient(conn)
+}
+
+func TestUnaryInterceptor(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		client, serverLogger, clientLogger := newTestClient(t)
+		res, err := client.Check(ctx, &health.HealthCheckRequest{Service: "ok"})
+		if err != nil {
+			t.Fatalf("Check() error = %v", err)
+		}
+		if res.Status != health.HealthCheckResponse_SERVING {
+			t.Errorf("Check() status = %v, want %v", res.Status, health.HealthCheckResponse_SERVING)
+		}
+		serverLogs := decodeLogs(t, serverLogger)
+		if len(serverLogs) != 1 {
+			t.Fatalf("expected 1 server log, got %d: %s", len(serverLogs), serverLogger.String())
+		}
+		if serverLogs[0].Message != "grpc /grpc.health.v1.Health/Check" || serverLogs[0].Code != 200 {
+			t.Errorf("unexpected server log: %s", serverLogger.String())
+		}
+		if !strings.Contains(string(serverLogs[0].Caller), "interceptor_test.go") {
+			t.Errorf("server log caller = %v, want to point to the handler in interceptor_test.go", serverLogs[0].Caller)
+		}
+		clientLogs := decodeLogs(t, clientLogger)
+		if len(clientLogs) != 1 {
+			t.Fatalf("expected 1 client log, got %d: %s", len(clientLogs), clientLogger.String())
+		}
+		if clientLogs[0].Message != "success: grpc /grpc.health.v1.Health/Check" || clientLogs[0].Code != 200 {
+			t.Errorf("unexpected client log: %s", clientLogger.String())
+		}
+		if !strings.Contains(string(clientLogs[0].Caller), "interceptor_test.go") {
+			t.Errorf("client log caller = %v, want to point to client.Check call in interceptor_test.go", clientLogs[0].Caller)
+		}
+		if !strings.Contains(string(clientLogs[0].Context), `"response":{"status":"SERVING"}`) {
+			t.Errorf("client log context = %s, want response payload", clientLogs[0].Context)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		client, serverLogger, clientLogger := newTestClient(t)
+		_, err := client.Check(ctx, &health.HealthCheckRequest{Service: "missing"})
+		st := status.Convert(err)
+		if st.Code() != codes.NotFound {
+			t.Errorf("status code = %v, want %v", st.Code(), codes.NotFound)
+		}
+		if st.Message() != "service not found" {
+			t.Errorf("status message = %v, want %v", st.Message(), "service not found")
+		}
+		serverLogs := decodeLogs(t, serverLogger)
+		if len(serverLogs) != 1 || serverLogs[0].Code != 404 || serverLogs[0].Level != "error" {
+			t.Errorf("unexpected server log: %s", serverLogger.String())
+		}
+		clientLogs := decodeLogs(t, clientLogger)
+		if len(clientLogs) != 1 || clientLogs[0].Code != 404 || !strings.Contains(string(clientLogs[0].Caller), "interceptor_test.go") {
+			t.Errorf("unexpected client log: %s", clientLogger.String())
+		}
+
+		merr := FromStatus(ctx, st)
+		if merr.Code() != 404 {
+			t.Errorf("FromStatus() code = %v, want %v", merr.Code(), 404)
+		}
+		if merr.Key() != "health" {
+			t.Errorf("FromStatus() key = %v, want %v", merr.Key(), "health")
+		}
+		if merr.Error() != "service not found" {
+			t.Errorf("FromStatus() error = %v, want %v", merr.Error(), "service not found")
+		}
+		if !strings.HasSuffix(merr.Caller().File(), "interceptor_test.go") {
+			t.Errorf("FromStatus() caller = %v, want to point to interceptor_test.go", merr.Caller())
+		}
+		b, _ := json.Marshal(merr.Context())
+		if string(b) != `[{"service":"missing"}]` {
+			t.Errorf("FromStatus() context = %s, want %s", b, `[{"service":"missing"}]`)
+		}
+		if got := ToStatus(merr); got.Code() != codes.NotFound || got.Message() != "service not found" {
+			t.Errorf("ToStatus(FromStatus()) = %v, want the received status", got)
+		}
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		client, serverLogger, _ := newTestClient(t)
+		_, err := client.Check(ctx, &health.HealthCheckRequest{Service: "panic"})
+		if st := status.Convert(err); st.Code() != codes.Internal || st.Message() != "Internal Server Error" {
+			t.Errorf("status = %v, want Internal with a generic message", st)
+		}
+		serverLogs := decodeLogs(t, serverLogger)
+		if len(serverLogs) != 1 || serverLogs[0].Code != 500 || serverLogs[0].Level != "panic" {
+			t.Errorf("unexpected server log: %s", serverLogger.String())
+		}
+	})
+}
+
+func TestUnaryInterceptor_NoTermination(t *testing.T) {
+	var exits int32
+	exit := func(int) { atomic.AddInt32(&exits, 1) }
+	serverLogger, clientLogger := maleo.NewTestingJSONLogger(), maleo.NewTestingJSONLogger()
+	serverMaleo := maleo.New(maleo.Service{Name: "server"}, maleo.Option.Init().Logger(serverLogger).ExitFunc(exit))
+	clientMaleo := maleo.New(maleo.Service{Name: "client"}, maleo.Option.Init().Logger(clientLogger).ExitFunc(exit))
+	client := newTestClientWith(t, serverMaleo, clientMaleo)
+
+	_, err := client.Check(context.Background(), &health.HealthCheckRequest{Service: "fatal"})
+	if st := status.Convert(err); st.Code() != codes.Unavailable {
+		t.Errorf("status code = %v, want %v", st.Code(), codes.Unavailable)
+	}
+	if got := atomic.LoadInt32(&exits); got != 0 {
+		t.Errorf("expected the interceptors not to terminate, got %d exits", got)
+	}
+	serverLogs := decodeLogs(t, serverLogger)
+	if len(serverLogs) != 1 || serverLogs[0].Level != "fatal" {
+		t.Errorf("expected the handler error logged at the fatal level, got: %s", serverLogger.String())
+	}
+	if clientLogs := decodeLogs(t, clientLogger); len(clientLogs) != 1 {
+		t.Errorf("expected 1 client log, got: %s", clientLogger.String())
+	}
+
+	// Client errors that the engine classifies at the fatal level, here returned by an inner interceptor.
+	errCircuitOpen := errors.New("circuit open")
+	clientMaleo.SetEngine(maleo.NewEngine(maleo.Option.Engine().ErrorClassifiers(
+		maleo.ClassifyIs(errCircuitOpen, maleo.Classification{Code: 503, Level: maleo.FatalLevel}),
+	)))
+	clientLogger.Reset()
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(
+			UnaryClientInterceptor(Option.Client().Maleo(clientMaleo)),
+			func(context.Context, string, any, any, *grpc.ClientConn, grpc.UnaryInvoker, ...grpc.CallOption) error {
+				return errCircuitOpen
+			},
+		),
+	)
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	_, _ = health.NewHealthClient(conn).Check(context.Background(), &health.HealthCheckRequest{})
+	if got := atomic.LoadInt32(&exits); got != 0 {
+		t.Errorf("expected the client interceptor not to terminate, got %d exits", got)
+	}
+	clientLogs := decodeLogs(t, clientLogger)
+	if len(clientLogs) != 1 || clientLogs[0].Level != "fatal" {
+		t.Errorf("expected the client error logged at the fatal level, got: %s", clientLogger.String())
+	}
+}
+
+func TestStreamInterceptor(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		client, serverLogger, clientLogger := newTestClient(t)
+		stream, err := client.Watch(ctx, &health.HealthCheckRequest{Service: "ok"})
+		if err != nil {
+			t.Fatalf("Watch() error = %v", err)
+		}
+		if _, err := stream.Recv(); err != nil {
+			t.Fatalf("Recv() error = %v", err)
+		}
+		if _, err := stream.Recv(); !errors.Is(err, io.EOF) {
+			t.Fatalf("Recv() error = %v, want io.EOF", err)
+		}
+		serverLogs := decodeLogs(t, serverLogger)
+		if len(serverLogs) != 1 || serverLogs[0].Message != "grpc /grpc.health.v1.Health/Watch" {
+			t.Errorf("unexpected server log: %s", serverLogger.String())
+		}
+		clientLogs := decodeLogs(t, clientLogger)
+		if len(clientLogs) != 1 || clientLogs[0].Message != "success: grpc /grpc.health.v1.Health/Watch" {
+			t.Fatalf("unexpected client log: %s", clientLogger.String())
+		}
+		if !strings.Contains(string(clientLogs[0].Caller), "interceptor_test.go") {
+			t.Errorf("client log caller = %v, want to point to client.Watch call in interceptor_test.go", clientLogs[0].Caller)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		client, serverLogger, clientLogger := newTestClient(t)
+		stream, err := client.Watch(ctx, &health.HealthCheckRequest{Service: "missing"})
+		if err != nil {
+			t.Fatalf("Watch() error = %v", err)
+		}
+		_, err = stream.Recv()
+		if st := status.Convert(err); st.Code() != codes.NotFound || st.Message() != "Not Found" {
+			t.Errorf("status = %v, want NotFound without the internal message", st)
+		}
+		serverLogs := decodeLogs(t, serverLogger)
+		if len(serverLogs) != 1 || serverLogs[0].Code != 404 {
+			t.Errorf("unexpected server log: %s", serverLogger.String())
+		}
+		clientLogs := decodeLogs(t, clientLogger)
+		if len(clientLogs) != 1 || clientLogs[0].Code != 404 {
+			t.Errorf("unexpected client log: %s", clientLogger.String())
+		}
+	})
+}
@@ -0,0 +1,14 @@
+package maleogrpc
+
+type option struct{}
+
+// Option holds all the available options for maleogrpc interceptors.
+var Option option
+
+func (option) Server() ServerOptionBuilder {
+	return ServerOptionBuilder{}
+}
+
+func (option) Client() ClientOptionBuilder {
+	return ClientOptionBuilder{}
+}
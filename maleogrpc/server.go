@@ -0,0 +1,194 @@
+package maleogrpc
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/tigorlazuardi/maleo"
+)
+
+type ServerCallContext struct {
+	Context    context.Context
+	FullMethod string
+	// Request is nil for stream calls.
+	Request any
+	// Response is nil for stream calls and failed calls.
+	Response any
+	// Stream is true if the call is a stream call.
+	Stream bool
+	// Error is the error returned by the handler, or the Error created from a recovered panic.
+	Error error
+	// Status is the status sent to the client. Status is nil if the call succeeded.
+	Status   *status.Status
+	Duration time.Duration
+	// Caller points to the method of the service implementation that handles the call.
+	Caller maleo.Caller
+	Maleo  *maleo.Maleo
+}
+
+type serverInterceptor struct {
+	maleo    *maleo.Maleo
+	filter   ServerFilterFunc
+	toStatus StatusFunc
+	log      ServerLogFunc
+}
+
+func newServerInterceptor(opts []ServerOption) *serverInterceptor {
+	s := &serverInterceptor{}
+	opts = append(defaultServerOptions(), opts...)
+	for _, opt := range opts {
+		opt.apply(s)
+	}
+	return s
+}
+
+/*
+UnaryServerInterceptor creates a grpc.UnaryServerInterceptor that logs the calls with maleo engine.
+
+Errors returned by the handlers are turned into gRPC status with ToStatus, so maleo Errors reach the clients with
+their HTTP code mapped to a gRPC code, their public message as the status message, and their Context attached as
+details. Panics are recovered and sent to the clients as codes.Internal.
+
+By default, the interceptor uses maleo's Global Instance.
+You may override this with maleogrpc.Option.Server().Maleo(*maleo.Maleo)
+
+Example:
+
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(maleogrpc.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(maleogrpc.StreamServerInterceptor()),
+	)
+*/
+func UnaryServerInterceptor(opts ...ServerOption) grpc.UnaryServerInterceptor {
+	s := newServerInterceptor(opts)
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (res any, err error) {
+		start := time.Now()
+		defer func() {
+			if v := recover(); v != nil {
+				err = s.panicError(v)
+				res = nil
+			}
+			call := &ServerCallContext{
+				Context:    ctx,
+				FullMethod: info.FullMethod,
+				Request:    req,
+				Error:      err,
+				Duration:   time.Since(start),
+				Caller:     handlerCaller(info.Server, info.FullMethod),
+				Maleo:      s.maleo,
+			}
+			if err == nil {
+				call.Response = res
+			}
+			err = s.finish(call)
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor creates a grpc.StreamServerInterceptor that logs the calls with maleo engine.
+// Errors and panics are handled the same way UnaryServerInterceptor does.
+func StreamServerInterceptor(opts ...ServerOption) grpc.StreamServerInterceptor {
+	s := newServerInterceptor(opts)
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		start := time.Now()
+		defer func() {
+			if v := recover(); v != nil {
+				err = s.panicError(v)
+			}
+			call := &ServerCallContext{
+				Context:    ss.Context(),
+				FullMethod: info.FullMethod,
+				Stream:     true,
+				Error:      err,
+				Duration:   time.Since(start),
+				Caller:     handlerCaller(srv, info.FullMethod),
+				Maleo:      s.maleo,
+			}
+			err = s.finish(call)
+		}()
+		return handler(srv, ss)
+	}
+}
+
+func (s *serverInterceptor) panicError(v any) error {
+	pe := maleo.NewPanicError(v)
+	return s.maleo.Wrap(pe).Caller(pe.PanicCaller).Level(maleo.PanicLevel).Code(500).Freeze()
+}
+
+// finish converts the error of the call to status and logs the call. Returns the error to send to the client.
+func (s *serverInterceptor) finish(call *ServerCallContext) error {
+	if call.Error != nil {
+		call.Status = s.toStatus(call.Error)
+	}
+	if s.filter(call.Context, call.FullMethod) {
+		s.log(call)
+	}
+	if call.Status == nil {
+		return call.Error
+	}
+	return call.Status.Err()
+}
+
+// handlerCaller looks up the method of the service implementation that handles fullMethod.
+//
+// Returns nil if the method cannot be found, e.g. for unknown service handlers.
+func handlerCaller(srv any, fullMethod string) maleo.Caller {
+	if srv == nil {
+		return nil
+	}
+	name := fullMethod[strings.LastIndexByte(fullMethod, '/')+1:]
+	method, ok := reflect.TypeOf(srv).MethodByName(name)
+	if !ok {
+		return nil
+	}
+	// CallerFromPC treats the pc as return address and looks up the previous instruction,
+	// so the entry is moved by one to land inside the method.
+	return maleo.CallerFromPC(method.Func.Pointer() + 1)
+}
+
+func defaultServerLogFunc(call *ServerCallContext) {
+	fields := maleo.F{
+		"method":   call.FullMethod,
+		"duration": call.Duration.String(),
+	}
+	if p, ok := peer.FromContext(call.Context); ok && p.Addr != nil {
+		fields["peer"] = p.Addr.String()
+	}
+	if call.Request != nil {
+		fields["request"] = payload(call.Request)
+	}
+	if call.Response != nil {
+		fields["response"] = payload(call.Response)
+	}
+	if call.Status != nil {
+		fields["status"] = call.Status.Code().String()
+		builder := call.Maleo.Wrap(call.Error).
+			Code(HTTPFromCode(call.Status.Code())).
+			Message("grpc %s", call.FullMethod).
+			Context(fields)
+		if call.Caller != nil {
+			builder = builder.Caller(call.Caller)
+		}
+		var merr maleo.Error
+		if errors.As(call.Error, &merr) {
+			builder = builder.Level(merr.Level())
+		}
+		// Handler errors at FatalLevel or PanicLevel are logged as such, but must not stop the whole server.
+		_ = builder.Log(maleo.ContextWithoutTermination(call.Context))
+		return
+	}
+	fields["status"] = "OK"
+	entry := call.Maleo.NewEntry("grpc %s", call.FullMethod).Code(200).Context(fields)
+	if call.Caller != nil {
+		entry = entry.Caller(call.Caller)
+	}
+	entry.Log(call.Context)
+}
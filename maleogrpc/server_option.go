@@ -0,0 +1,73 @@
+package maleogrpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/status"
+
+	"github.com/tigorlazuardi/maleo"
+)
+
+type (
+	// ServerFilterFunc reports whether the call should be logged.
+	ServerFilterFunc = func(ctx context.Context, fullMethod string) bool
+	// StatusFunc turns the error returned by a handler into the status sent to the client.
+	StatusFunc    = func(err error) *status.Status
+	ServerLogFunc = func(call *ServerCallContext)
+)
+
+type ServerOption interface {
+	apply(*serverInterceptor)
+}
+
+type (
+	ServerOptionFunc    func(*serverInterceptor)
+	ServerOptionBuilder []ServerOption
+)
+
+func (s ServerOptionFunc) apply(interceptor *serverInterceptor) {
+	s(interceptor)
+}
+
+func (s ServerOptionBuilder) apply(interceptor *serverInterceptor) {
+	for _, opt := range s {
+		opt.apply(interceptor)
+	}
+}
+
+// Maleo sets the maleo instance to use for logging.
+func (s ServerOptionBuilder) Maleo(m *maleo.Maleo) ServerOptionBuilder {
+	return append(s, ServerOptionFunc(func(interceptor *serverInterceptor) {
+		interceptor.maleo = m
+	}))
+}
+
+// Filter sets the filter of calls to log, e.g. to skip health checks. Errors are still converted to status
+// for filtered out calls.
+func (s ServerOptionBuilder) Filter(filter ServerFilterFunc) ServerOptionBuilder {
+	return append(s, ServerOptionFunc(func(interceptor *serverInterceptor) {
+		interceptor.filter = filter
+	}))
+}
+
+// Status sets the function that turns errors returned by the handlers into status. Defaults to ToStatus.
+func (s ServerOptionBuilder) Status(fn StatusFunc) ServerOptionBuilder {
+	return append(s, ServerOptionFunc(func(interceptor *serverInterceptor) {
+		interceptor.toStatus = fn
+	}))
+}
+
+// Log sets the function that logs the calls.
+func (s ServerOptionBuilder) Log(log ServerLogFunc) ServerOptionBuilder {
+	return append(s, ServerOptionFunc(func(interceptor *serverInterceptor) {
+		interceptor.log = log
+	}))
+}
+
+func defaultServerOptions() ServerOptionBuilder {
+	return Option.Server().
+		Maleo(maleo.Global()).
+		Filter(func(context.Context, string) bool { return true }).
+		Status(ToStatus).
+		Log(defaultServerLogFunc)
+}
@@ -0,0 +1,226 @@
+package maleogrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/tigorlazuardi/maleo"
+)
+
+type grpcStatus interface {
+	GRPCStatus() *status.Status
+}
+
+// CodeFromHTTP maps maleo Error codes, which by convention are HTTP status codes, to gRPC codes.
+func CodeFromHTTP(code int) codes.Code {
+	switch code {
+	case 400:
+		return codes.InvalidArgument
+	case 401:
+		return codes.Unauthenticated
+	case 403:
+		return codes.PermissionDenied
+	case 404:
+		return codes.NotFound
+	case 409:
+		return codes.AlreadyExists
+	case 412:
+		return codes.FailedPrecondition
+	case 416:
+		return codes.OutOfRange
+	case 429:
+		return codes.ResourceExhausted
+	case 499:
+		return codes.Canceled
+	case 501:
+		return codes.Unimplemented
+	case 503:
+		return codes.Unavailable
+	case 504:
+		return codes.DeadlineExceeded
+	}
+	switch {
+	case code >= 200 && code < 300:
+		return codes.OK
+	case code >= 400 && code < 500:
+		return codes.FailedPrecondition
+	case code >= 500 && code < 600:
+		return codes.Internal
+	}
+	return codes.Unknown
+}
+
+// HTTPFromCode maps gRPC codes to HTTP status codes, which is the convention of maleo Error codes.
+func HTTPFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return 200
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return 400
+	case codes.DeadlineExceeded:
+		return 504
+	case codes.NotFound:
+		return 404
+	case codes.AlreadyExists, codes.Aborted:
+		return 409
+	case codes.PermissionDenied:
+		return 403
+	case codes.ResourceExhausted:
+		return 429
+	case codes.Unimplemented:
+		return 501
+	case codes.Unavailable:
+		return 503
+	case codes.Unauthenticated:
+		return 401
+	}
+	return 500
+}
+
+/*
+ToStatus turns err into a gRPC status.
+
+If err is, or wraps, a maleo Error, the status code is mapped from the HTTP code of the error, found by
+maleo.Query.GetHTTPCode, with CodeFromHTTP, and the Error's key, code and context are attached as a
+google.protobuf.Struct detail, which FromStatus reads back.
+If the maleo Error wraps an error with a gRPC status whose code maps to the same HTTP code, e.g. an Error created by
+FromStatus, the wrapped gRPC code and message are kept as is.
+
+The status message is the public message of the error, found by maleo.Query.GetPublicMessage, so the internal message
+and the wrapped errors do not leak to the clients. If the error has no public message, the text of the HTTP code, e.g.
+"Not Found", is used instead.
+
+Other errors are converted with status.Convert. Returns nil if err is nil.
+*/
+func ToStatus(err error) *status.Status {
+	if err == nil {
+		return nil
+	}
+	var merr maleo.Error
+	if !errors.As(err, &merr) {
+		return status.Convert(err)
+	}
+	httpCode := maleo.Query.GetHTTPCode(err)
+	code := CodeFromHTTP(httpCode)
+	var message string
+	var gs grpcStatus
+	if errors.As(merr, &gs) {
+		if inner := gs.GRPCStatus(); inner != nil && HTTPFromCode(inner.Code()) == httpCode {
+			code = inner.Code()
+			message = inner.Message()
+		}
+	}
+	if msg := maleo.Query.GetPublicMessage(err); msg != "" {
+		message = msg
+	}
+	if message == "" {
+		message = http.StatusText(httpCode)
+	}
+	if message == "" {
+		message = code.String()
+	}
+	st := status.New(code, message)
+	detail, derr := structpb.NewStruct(errorDetail(merr))
+	if derr != nil {
+		return st
+	}
+	if withDetails, derr := st.WithDetails(detail); derr == nil {
+		st = withDetails
+	}
+	return st
+}
+
+func errorDetail(err maleo.Error) map[string]any {
+	detail := map[string]any{
+		"code": err.Code(),
+	}
+	if key := err.Key(); key != "" {
+		detail["key"] = key
+	}
+	var data any
+	switch ctx := err.Context(); len(ctx) {
+	case 0:
+	case 1:
+		data = ctx[0]
+	default:
+		data = ctx
+	}
+	if data != nil {
+		// structpb only accepts plain JSON values, so the context goes through a JSON round trip first.
+		if b, e := json.Marshal(data); e == nil {
+			var v any
+			if json.Unmarshal(b, &v) == nil {
+				detail["context"] = v
+			}
+		} else {
+			detail["context"] = fmt.Sprint(data)
+		}
+	}
+	return detail
+}
+
+// StatusError is the origin error of Errors created by FromStatus.
+type StatusError struct {
+	Status *status.Status
+}
+
+func (s *StatusError) Error() string {
+	return s.Status.Message()
+}
+
+// GRPCStatus returns the received status, so the status is kept when the error is returned by a gRPC handler.
+func (s *StatusError) GRPCStatus() *status.Status {
+	return s.Status
+}
+
+// Code implements maleo.CodeHint. Returns the HTTP status code mapped from the gRPC code.
+func (s *StatusError) Code() int {
+	return HTTPFromCode(s.Status.Code())
+}
+
+/*
+FromStatus rebuilds a maleo Error from a status received from a gRPC call, e.g. status.Convert(err) of a client call.
+
+The key, code and context attached by ToStatus on the server side are restored. Otherwise the code is mapped from
+the gRPC code with HTTPFromCode. The Error is created by the Maleo instance attached to ctx, or the Global instance
+if there is none, and has the location where FromStatus is called as Caller.
+
+Returns nil if st is nil or has codes.OK.
+*/
+func FromStatus(ctx context.Context, st *status.Status) maleo.Error {
+	if st == nil || st.Code() == codes.OK {
+		return nil
+	}
+	m := maleo.MaleoFromContext(ctx)
+	if m == nil {
+		m = maleo.Global()
+	}
+	builder := m.Wrap(&StatusError{Status: st}).Caller(maleo.GetCaller(2))
+	for _, detail := range st.Details() {
+		s, ok := detail.(*structpb.Struct)
+		if !ok {
+			continue
+		}
+		fields := s.AsMap()
+		if code, ok := fields["code"].(float64); ok {
+			builder = builder.Code(int(code))
+		}
+		if key, ok := fields["key"].(string); ok {
+			builder = builder.Key(key)
+		}
+		if data, ok := fields["context"]; ok {
+			builder = builder.Context(data)
+		}
+		break
+	}
+	return builder.Freeze()
+}
@@ -0,0 +1,21 @@
+package maleogrpc
+
+import (
+	"encoding/json"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// payload turns protobuf messages into JSON, so they are logged with their field names.
+func payload(v any) any {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return v
+	}
+	b, err := protojson.Marshal(msg)
+	if err != nil {
+		return v
+	}
+	return json.RawMessage(b)
+}
@@ -0,0 +1,93 @@
+package maleohttp
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/tigorlazuardi/maleo"
+)
+
+func TestLoggerHook_Redact(t *testing.T) {
+	logger := maleo.NewTestingJSONLogger()
+	mal := maleo.New(maleo.Service{Name: "responder-test"})
+	mal.SetLogger(logger)
+	mal.SetEngine(maleo.NewEngine(maleo.Option.Engine().Redactor(maleo.NewRedactor(maleo.Option.Redact().Defaults()))))
+
+	r := NewResponder()
+	r.SetMaleo(mal)
+	r.RegisterHook(NewLoggerHook())
+	handler := r.RequestBodyCloner()(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		r.Respond(writer, request, map[string]any{"access_token": "issued-token", "user": "kilua"})
+	}))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(`{"user":"kilua","password":"hunter2"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer secret-bearer")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+
+	out := logger.String()
+	for _, leaked := range []string{"hunter2", "secret-bearer", "issued-token"} {
+		if strings.Contains(out, leaked) {
+			t.Errorf("log leaks %q", leaked)
+		}
+	}
+	if !strings.Contains(out, "kilua") {
+		t.Errorf("log misses the user")
+	}
+	if t.Failed() {
+		logger.PrettyPrint()
+	}
+}
+
+func TestRoundTrip_Redact(t *testing.T) {
+	logger := maleo.NewTestingJSONLogger()
+	mal := maleo.New(maleo.Service{Name: "round-trip-test"})
+	mal.SetLogger(logger)
+	mal.SetEngine(maleo.NewEngine(maleo.Option.Engine().Redactor(maleo.NewRedactor(maleo.Option.Redact().Defaults()))))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=secret-session")
+		_, _ = w.Write([]byte(`{"access_token":"issued-token","user":"kilua"}`))
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(`{"user":"kilua","password":"hunter2"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer secret-bearer")
+	client := &http.Client{Transport: NewRoundTrip(Option.RoundTrip().Maleo(mal))}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+
+	out := logger.String()
+	for _, leaked := range []string{"hunter2", "secret-bearer", "issued-token"} {
+		if strings.Contains(out, leaked) {
+			t.Errorf("log leaks %q", leaked)
+		}
+	}
+	if !strings.Contains(out, "Authorization") || !strings.Contains(out, "kilua") {
+		t.Errorf("log misses the redacted header or the user")
+	}
+	if t.Failed() {
+		logger.PrettyPrint()
+	}
+}
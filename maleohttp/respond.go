@@ -28,7 +28,7 @@ type Responder struct {
 //
 // - BodyTransformer: NoopBodyTransform (does nothing to whatever value you pass in)
 //
-// - ErrorBodyTransformer: SimpleErrorTransformer (encodes error to {"error": "message/err.Error()"}) with JSONEncoder.
+// - ErrorBodyTransformer: SimpleErrorTransformer (encodes error to {"error": "message"}, hiding 5xx messages) with JSONEncoder.
 // Different Encoder may have different output.
 //
 // - Maleo: points to the global maleo instance
@@ -20,8 +20,8 @@ const errInternalServerError errString = "Internal Server Error"
 //
 // errPayload is expected to be a serializable type.
 //
-// HTTP Status code by default is http.StatusInternalServerError. If error implements maleo.HTTPCodeHint, the status code will be set to the
-// value returned by the maleo.HTTPCodeHint method. If the maleohttp.Option.StatusCode RespondOption is set, it will override
+// HTTP Status code by default is http.StatusInternalServerError. The status code is found by maleo.Query.GetHTTPCode, so an
+// explicit HTTP code set on the error takes precedence over the HTTP code of the maleo.Definition the error is built from. If the maleohttp.Option.StatusCode RespondOption is set, it will override
 // the status regardless of the maleo.HTTPCodeHint.
 //
 // if err is nil, it will be replaced with "Internal Server Error" message. It is done this way, because the library
@@ -114,7 +114,7 @@ func TestResponder_RespondError(t *testing.T) {
 				if len(body) == 0 {
 					t.Error("expected response body, got empty")
 				}
-				wantBody := `{"error":"test error"}`
+				wantBody := `{"error":"Internal Server Error"}`
 				j := jsonassert.New(t)
 				j.Assertf(string(body), wantBody)
 				wantLog := `
@@ -145,11 +145,11 @@ func TestResponder_RespondError(t *testing.T) {
 						},
 						"response": {
 							"body": {
-								"error": "test error"
+								"error": "Internal Server Error"
 							},
 							"headers": {
 								"Content-Length": [
-									"23"
+									"34"
 								],
 								"Content-Type": [
 									"application/json"
@@ -29,7 +29,7 @@ func defaultLoggerOptions() RespondHookOptionBuilder {
 }
 
 func defaultLoggerRespond(ctx *RespondHookContext) {
-	fields := buildLoggerFields(ctx.baseHook, ctx.ResponseBody.PostEncoded, false)
+	fields := redactFields(ctx.Maleo, buildLoggerFields(ctx.baseHook, ctx.ResponseBody.PostEncoded, false))
 	message := fmt.Sprintf("%s %s %s", ctx.Request.Method, ctx.Request.URL.String(), ctx.Request.Proto)
 	if ctx.Error != nil {
 		_ = ctx.Maleo.Wrap(ctx.Error).Level(maleo.ErrorLevel).Code(ctx.ResponseStatus).Message(message).Caller(ctx.Context.Caller).Context(fields).Log(ctx.Request.Context())
@@ -39,7 +39,7 @@ func defaultLoggerRespond(ctx *RespondHookContext) {
 }
 
 func defaultLoggerRespondError(ctx *RespondErrorHookContext) {
-	fields := buildLoggerFields(ctx.baseHook, ctx.ResponseBody.PostEncoded, false)
+	fields := redactFields(ctx.Maleo, buildLoggerFields(ctx.baseHook, ctx.ResponseBody.PostEncoded, false))
 	message := fmt.Sprintf("%s %s %s", ctx.Request.Method, ctx.Request.URL.String(), ctx.Request.Proto)
 	if ctx.Error != nil {
 		_ = ctx.Maleo.Wrap(ctx.Error).Code(ctx.ResponseStatus).Message(message).Caller(ctx.Context.Caller).Context(fields).Log(ctx.Request.Context())
@@ -49,7 +49,7 @@ func defaultLoggerRespondError(ctx *RespondErrorHookContext) {
 }
 
 func defaultLoggerRespondStream(ctx *RespondStreamHookContext) {
-	fields := buildLoggerFields(ctx.baseHook, ctx.ResponseBody.Value.CloneBytes(), ctx.ResponseBody.Value.Truncated())
+	fields := redactFields(ctx.Maleo, buildLoggerFields(ctx.baseHook, ctx.ResponseBody.Value.CloneBytes(), ctx.ResponseBody.Value.Truncated()))
 	message := fmt.Sprintf("%s %s %s", ctx.Request.Method, ctx.Request.URL.String(), ctx.Request.Proto)
 	if ctx.Error != nil {
 		_ = ctx.Maleo.Wrap(ctx.Error).Level(maleo.ErrorLevel).Code(ctx.ResponseStatus).Message(message).Caller(ctx.Context.Caller).Context(fields).Log(ctx.Request.Context())
@@ -108,6 +108,18 @@ func buildLoggerFields(hook *baseHook, respBody []byte, truncated bool) maleo.F
 	}
 }
 
+// redactFields masks the sensitive headers and bodies in fields with the Redactor of m, before they are handed to the
+// Entry or Error.
+func redactFields(m *maleo.Maleo, fields maleo.F) maleo.F {
+	switch redacted := m.Redact(fields).(type) {
+	case maleo.F:
+		return redacted
+	case map[string]any:
+		return redacted
+	}
+	return fields
+}
+
 func isJsonLite(b []byte) bool {
 	if len(b) < 2 {
 		return false
@@ -994,7 +994,7 @@ func TestResponder_Respond(t *testing.T) {
 						},
 						"response": {
 							"body": {
-								"error": "json: unsupported type: func()"
+								"error": "Internal Server Error"
 							},
 							"headers": {
 								"Content-Length": [
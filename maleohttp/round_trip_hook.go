@@ -120,6 +120,7 @@ func defaultRounTripLogFunc(ctx *RoundTripContext) {
 	if ctx.Response != nil {
 		fields = buildClientResponseFields(fields, ctx.Response, ctx.ResponseBody)
 	}
+	fields = redactFields(ctx.Maleo, fields)
 	if ctx.Error != nil {
 		builder := ctx.Maleo.Wrap(ctx.Error).Context(fields)
 		if ctx.Response != nil {
@@ -3,7 +3,7 @@ package maleohttp
 import (
 	"context"
 	"encoding/json"
-	"errors"
+	"net/http"
 
 	"github.com/tigorlazuardi/maleo"
 )
@@ -40,13 +40,33 @@ func (n NoopBodyTransform) BodyTransform(_ context.Context, input any) any {
 	return input
 }
 
+/*
+SimpleErrorTransformer encodes the error to {"error": "message"}.
+
+The message is the public message of the error, found by maleo.Query.GetPublicMessage. Errors built from a
+maleo.Definition carry the message of the Definition as the public message. If the error has no public message and
+the error is built from a Definition, or the HTTP code of the error is 5xx, a generic message like "Internal Server
+Error" is used instead, so internal details do not leak to the clients. Otherwise the message hint, the error itself
+if it implements json.Marshaler, or err.Error() is used.
+
+Logs and Messengers still receive the internal message.
+*/
 type SimpleErrorTransformer struct{}
 
 func (n SimpleErrorTransformer) ErrorBodyTransform(_ context.Context, err error) any {
-	var msg any
 	if err == nil {
-		err = errors.New("[nil]")
+		return map[string]any{"error": "[nil]"}
+	}
+	if msg := maleo.Query.GetPublicMessage(err); msg != "" {
+		return map[string]any{"error": msg}
+	}
+	if _, ok := maleo.FindDefinition(err); ok {
+		return map[string]any{"error": genericErrorMessage(maleo.Query.GetHTTPCode(err))}
 	}
+	if code := maleo.Query.GetHTTPCode(err); code >= 500 {
+		return map[string]any{"error": genericErrorMessage(code)}
+	}
+	var msg any
 	switch err := err.(type) {
 	case maleo.MessageHint:
 		msg = err.Message()
@@ -57,3 +77,10 @@ func (n SimpleErrorTransformer) ErrorBodyTransform(_ context.Context, err error)
 	}
 	return map[string]any{"error": msg}
 }
+
+func genericErrorMessage(code int) string {
+	if text := http.StatusText(code); text != "" {
+		return text
+	}
+	return string(errInternalServerError)
+}
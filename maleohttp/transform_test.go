@@ -3,12 +3,22 @@ package maleohttp
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
+
+	"github.com/tigorlazuardi/maleo"
 )
 
 type errorJson struct {
 	Message string `json:"message"`
+	Status  int    `json:"-"`
+}
+
+func (e errorJson) HTTPCode() int {
+	return e.Status
 }
 
 func (e errorJson) MarshalJSON() ([]byte, error) {
@@ -19,7 +29,49 @@ func (e errorJson) Error() string {
 	return e.Message
 }
 
+var errUserNotFound = maleo.Define(40401, "user.not_found", "user %s not found",
+	maleo.Option.Definition().HTTPCode(http.StatusNotFound).Registry(nil),
+)
+
+func TestResponder_RespondError_DefinitionHTTPCode(t *testing.T) {
+	m, _ := maleo.NewTestingMaleo()
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{
+			name: "definition",
+			err:  m.Wrap(errUserNotFound.New("42").Freeze()).Message("failed to get user").Freeze(),
+			want: http.StatusNotFound,
+		},
+		{
+			name: "explicit HTTP code on top of a definition",
+			err:  m.Wrap(errUserNotFound.New("42").Freeze()).HTTPCode(http.StatusServiceUnavailable).Freeze(),
+			want: http.StatusServiceUnavailable,
+		},
+		{
+			name: "plain",
+			err:  m.Bail("plain").Code(http.StatusConflict).Freeze(),
+			want: http.StatusConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			NewResponder().RespondError(rec, httptest.NewRequest(http.MethodGet, "/users/42", nil), tt.err)
+			if rec.Code != tt.want {
+				t.Errorf("RespondError() status = %d, want %d", rec.Code, tt.want)
+			}
+			if got := maleo.Query.GetHTTPCode(tt.err); got != tt.want {
+				t.Errorf("Query.GetHTTPCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestSimpleErrorTransformer_ErrorBodyTransform(t *testing.T) {
+	m, _ := maleo.NewTestingMaleo()
 	type args struct {
 		in0 context.Context
 		err error
@@ -41,9 +93,61 @@ func TestSimpleErrorTransformer_ErrorBodyTransform(t *testing.T) {
 			name: "handled json marshaler implementor",
 			args: args{
 				in0: context.Background(),
-				err: errorJson{Message: "test"},
+				err: errorJson{Message: "test", Status: http.StatusBadRequest},
+			},
+			want: map[string]interface{}{"error": errorJson{Message: "test", Status: http.StatusBadRequest}},
+		},
+		{
+			name: "hides internal message on 5xx",
+			args: args{
+				in0: context.Background(),
+				err: errors.New("dial tcp 10.0.0.1:5432: connection refused"),
+			},
+			want: map[string]interface{}{"error": "Internal Server Error"},
+		},
+		{
+			name: "generic message follows the http code",
+			args: args{
+				in0: context.Background(),
+				err: m.Bail("upstream is down").Code(http.StatusServiceUnavailable).Freeze(),
+			},
+			want: map[string]interface{}{"error": "Service Unavailable"},
+		},
+		{
+			name: "message hint is used on 4xx",
+			args: args{
+				in0: context.Background(),
+				err: m.Bail("invalid id").Code(http.StatusBadRequest).Freeze(),
+			},
+			want: map[string]interface{}{"error": "invalid id"},
+		},
+		{
+			name: "public message is used on 5xx",
+			args: args{
+				in0: context.Background(),
+				err: m.Bail("query failed: relation users does not exist").
+					PublicMessage("please try again later").Freeze(),
+			},
+			want: map[string]interface{}{"error": "please try again later"},
+		},
+		{
+			name: "public message is found in the chain",
+			args: args{
+				in0: context.Background(),
+				err: m.Wrap(
+					m.Bail("user 42 not found").Code(http.StatusNotFound).PublicMessage("user not found").Freeze(),
+				).Message("failed to get user").Freeze(),
+			},
+			want: map[string]interface{}{"error": "user not found"},
+		},
+		{
+			name: "definition message is used",
+			args: args{
+				in0: context.Background(),
+				err: m.Wrap(errUserNotFound.Wrap(errors.New("sql: no rows in result set"), "42").Freeze()).
+					Code(http.StatusInternalServerError).Message("failed to get user").Freeze(),
 			},
-			want: map[string]interface{}{"error": errorJson{Message: "test"}},
+			want: map[string]interface{}{"error": "user 42 not found"},
 		},
 	}
 	for _, tt := range tests {
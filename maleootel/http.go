@@ -0,0 +1,56 @@
+package maleootel
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ErrorBodyTransformer has the same method set as maleohttp.ErrorBodyTransformer,
+// so maleootel does not have to depend on maleohttp.
+type ErrorBodyTransformer interface {
+	ErrorBodyTransform(ctx context.Context, err error) any
+}
+
+// TraceErrorBodyTransformer adds the trace id and the trace url to the HTTP error bodies created by the next
+// ErrorBodyTransformer, so clients can report the trace of a failed request.
+//
+// The trace is added only if the body created by the next transformer is a map[string]any,
+// like the body from maleohttp.SimpleErrorTransformer.
+type TraceErrorBodyTransformer struct {
+	next    ErrorBodyTransformer
+	capture *TraceCapturer
+}
+
+// NewErrorBodyTransformer creates a new TraceErrorBodyTransformer. Set it to maleohttp.Responder
+// with Responder.SetErrorTransformer.
+//
+// Example:
+//
+//	responder.SetErrorTransformer(maleootel.NewErrorBodyTransformer(maleohttp.SimpleErrorTransformer{}, capturer))
+//
+// The keys and the trace url are taken from capturer. If capturer is nil, NewTraceCapturer() is used.
+func NewErrorBodyTransformer(next ErrorBodyTransformer, capturer *TraceCapturer) *TraceErrorBodyTransformer {
+	if capturer == nil {
+		capturer = NewTraceCapturer()
+	}
+	return &TraceErrorBodyTransformer{next: next, capture: capturer}
+}
+
+// ErrorBodyTransform implements maleohttp.ErrorBodyTransformer.
+func (t *TraceErrorBodyTransformer) ErrorBodyTransform(ctx context.Context, err error) any {
+	body := t.next.ErrorBodyTransform(ctx, err)
+	m, ok := body.(map[string]any)
+	if !ok {
+		return body
+	}
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return body
+	}
+	m[t.capture.traceIDKey] = sc.TraceID().String()
+	if url := t.capture.traceURL(sc); url != "" {
+		m[t.capture.traceURLKey] = url
+	}
+	return m
+}
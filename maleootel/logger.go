@@ -0,0 +1,139 @@
+package maleootel
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/tigorlazuardi/maleo"
+)
+
+var _ maleo.Logger = (*Logger)(nil)
+
+// Logger records maleo Entries and Errors as events of the span in context.Context,
+// then passes them on to the next Logger.
+//
+// Errors with level at or above the status level also set the span status to error.
+type Logger struct {
+	next        maleo.Logger
+	statusLevel maleo.Level
+}
+
+// NewLogger creates a new Logger. next is the Logger that receives the Entries and Errors after they are recorded
+// to the span, e.g. maleozap.Logger. next may be nil.
+func NewLogger(next maleo.Logger, opts ...LoggerOption) *Logger {
+	if next == nil {
+		next = maleo.NoopLogger{}
+	}
+	l := &Logger{
+		next:        next,
+		statusLevel: maleo.ErrorLevel,
+	}
+	for _, opt := range opts {
+		opt.Apply(l)
+	}
+	return l
+}
+
+// Log implements maleo.Logger. The entry is recorded as span event with the entry's message as event name.
+func (l *Logger) Log(ctx context.Context, entry maleo.Entry) {
+	span := trace.SpanFromContext(ctx)
+	if span.IsRecording() {
+		attrs := make([]attribute.KeyValue, 0, 8)
+		attrs = append(attrs, attribute.String("maleo.level", entry.Level().String()))
+		if code := entry.Code(); code != 0 {
+			attrs = append(attrs, attribute.Int("maleo.code", code))
+		}
+		attrs = appendCommonAttributes(attrs, entry, entry.Caller(), entry.Key(), entry.Context())
+		span.AddEvent(entry.Message(), trace.WithAttributes(attrs...), trace.WithTimestamp(entry.Time()))
+	}
+	l.next.Log(ctx, entry)
+}
+
+// LogError implements maleo.Logger. The error is recorded as "exception" span event,
+// following the OpenTelemetry semantic conventions for exceptions.
+func (l *Logger) LogError(ctx context.Context, err maleo.Error) {
+	span := trace.SpanFromContext(ctx)
+	if span.IsRecording() {
+		attrs := make([]attribute.KeyValue, 0, 12)
+		attrs = append(attrs,
+			attribute.String("exception.type", exceptionType(err)),
+			attribute.String("exception.message", err.Error()),
+			attribute.String("maleo.level", err.Level().String()),
+			attribute.Int("maleo.code", err.Code()),
+		)
+		if sh, ok := err.(maleo.StackHint); ok {
+			if frames := sh.Frames(); len(frames) > 0 {
+				attrs = append(attrs, attribute.String("exception.stacktrace", formatFrames(frames)))
+			}
+		}
+		attrs = appendCommonAttributes(attrs, err, err.Caller(), err.Key(), err.Context())
+		span.AddEvent("exception", trace.WithAttributes(attrs...), trace.WithTimestamp(err.Time()))
+		if l.statusLevel.Enabled(err.Level()) {
+			span.SetStatus(codes.Error, err.Message())
+		}
+	}
+	l.next.LogError(ctx, err)
+}
+
+func appendCommonAttributes(attrs []attribute.KeyValue, v any, caller maleo.Caller, key string, data []any) []attribute.KeyValue {
+	if caller != nil {
+		attrs = append(attrs,
+			attribute.String("code.filepath", caller.File()),
+			attribute.Int("code.lineno", caller.Line()),
+			attribute.String("code.function", caller.ShortName()),
+		)
+	}
+	if key != "" {
+		attrs = append(attrs, attribute.String("maleo.key", key))
+	}
+	if ch, ok := v.(maleo.ComponentHint); ok {
+		if component := ch.Component(); component != "" {
+			attrs = append(attrs, attribute.String("maleo.component", component))
+		}
+	}
+	if cf, ok := v.(maleo.ContextFieldsHint); ok {
+		if fields := cf.ContextFields(); len(fields) > 0 {
+			attrs = append(attrs, attribute.String("maleo.context_fields", toJSON(fields)))
+		}
+	}
+	switch len(data) {
+	case 0:
+	case 1:
+		attrs = append(attrs, attribute.String("maleo.context", toJSON(data[0])))
+	default:
+		attrs = append(attrs, attribute.String("maleo.context", toJSON(maleo.ContextMap(data))))
+	}
+	return attrs
+}
+
+// exceptionType returns the type of the root cause, since maleo.Error is only a wrapper.
+func exceptionType(err error) string {
+	return fmt.Sprintf("%T", maleo.Query.Cause(err))
+}
+
+func formatFrames(frames []maleo.Caller) string {
+	s := &strings.Builder{}
+	for i, frame := range frames {
+		if i > 0 {
+			s.WriteString("\n")
+		}
+		s.WriteString(frame.ShortName())
+		s.WriteString("\n\t")
+		s.WriteString(frame.String())
+	}
+	return s.String()
+}
+
+func toJSON(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
@@ -0,0 +1,20 @@
+package maleootel
+
+import "github.com/tigorlazuardi/maleo"
+
+type LoggerOption interface {
+	Apply(*Logger)
+}
+
+type LoggerOptionFunc func(*Logger)
+
+func (f LoggerOptionFunc) Apply(l *Logger) {
+	f(l)
+}
+
+// WithStatusLevel sets the minimum level of Errors that set the span status to error. Defaults to maleo.ErrorLevel.
+func WithStatusLevel(lvl maleo.Level) LoggerOption {
+	return LoggerOptionFunc(func(l *Logger) {
+		l.statusLevel = lvl
+	})
+}
@@ -0,0 +1,98 @@
+package maleootel
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/tigorlazuardi/maleo"
+)
+
+func newTestTracer() (*tracetest.InMemoryExporter, *sdktrace.TracerProvider) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	return exporter, provider
+}
+
+func attributeMap(kvs []attribute.KeyValue) map[attribute.Key]attribute.Value {
+	out := make(map[attribute.Key]attribute.Value, len(kvs))
+	for _, kv := range kvs {
+		out[kv.Key] = kv.Value
+	}
+	return out
+}
+
+func TestLogger(t *testing.T) {
+	exporter, provider := newTestTracer()
+	m, logger := maleo.NewTestingMaleo()
+	m.SetLogger(NewLogger(logger))
+
+	ctx, span := provider.Tracer("test").Start(context.Background(), "operation")
+	m.NewEntry("cache miss").Key("cache").Context("key", "user:1").Log(ctx)
+	_ = m.Named("db").Wrap(errors.New("connection refused"), "failed to query").Code(503).Log(ctx)
+	_ = m.Bail("slow").Level(maleo.WarnLevel).Log(ctx)
+	span.End()
+
+	if len(logger.Bytes()) == 0 {
+		t.Error("expected the next logger to be called")
+	}
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	got := spans[0]
+	if got.Status.Code != codes.Error || got.Status.Description != "failed to query" {
+		t.Errorf("span status = %+v, want error with description 'failed to query'", got.Status)
+	}
+	if len(got.Events) != 3 {
+		t.Fatalf("expected 3 span events, got %d", len(got.Events))
+	}
+
+	entry := got.Events[0]
+	if entry.Name != "cache miss" {
+		t.Errorf("entry event name = %v, want %v", entry.Name, "cache miss")
+	}
+	attrs := attributeMap(entry.Attributes)
+	if attrs["maleo.key"].AsString() != "cache" {
+		t.Errorf("entry maleo.key = %v, want %v", attrs["maleo.key"].AsString(), "cache")
+	}
+	if attrs["maleo.context"].AsString() != `{"key":"user:1"}` {
+		t.Errorf("entry maleo.context = %v, want %v", attrs["maleo.context"].AsString(), `{"key":"user:1"}`)
+	}
+	if attrs["code.function"].AsString() != "maleootel.TestLogger" {
+		t.Errorf("entry code.function = %v, want %v", attrs["code.function"].AsString(), "maleootel.TestLogger")
+	}
+
+	exception := got.Events[1]
+	if exception.Name != "exception" {
+		t.Errorf("error event name = %v, want %v", exception.Name, "exception")
+	}
+	attrs = attributeMap(exception.Attributes)
+	if attrs["exception.type"].AsString() != "*errors.errorString" {
+		t.Errorf("exception.type = %v, want %v", attrs["exception.type"].AsString(), "*errors.errorString")
+	}
+	if attrs["exception.message"].AsString() != "failed to query: connection refused" {
+		t.Errorf("exception.message = %v, want %v", attrs["exception.message"].AsString(), "failed to query: connection refused")
+	}
+	if attrs["maleo.code"].AsInt64() != 503 {
+		t.Errorf("maleo.code = %v, want %v", attrs["maleo.code"].AsInt64(), 503)
+	}
+	if attrs["maleo.component"].AsString() != "db" {
+		t.Errorf("maleo.component = %v, want %v", attrs["maleo.component"].AsString(), "db")
+	}
+}
+
+func TestLogger_NoSpan(t *testing.T) {
+	m, logger := maleo.NewTestingMaleo()
+	m.SetLogger(NewLogger(logger, WithStatusLevel(maleo.WarnLevel)))
+	_ = m.Bail("no span").Log(context.Background())
+	if len(logger.Bytes()) == 0 {
+		t.Error("expected the next logger to be called")
+	}
+	NewLogger(nil).Log(context.Background(), m.NewEntry("nil next").Freeze())
+}
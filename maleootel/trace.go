@@ -0,0 +1,72 @@
+package maleootel
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/tigorlazuardi/maleo"
+)
+
+var _ maleo.TraceCapturer = (*TraceCapturer)(nil)
+
+// TraceCapturer captures the trace_id and span_id from the OpenTelemetry span context in context.Context.
+//
+// TraceCapturer implements maleo.TraceCapturer, so it can be used by Messengers like maleodiscord.
+// Use maleozap.TraceCapturerFromMaleo or maleoslog.TraceCapturerFromMaleo to use it with the loggers.
+type TraceCapturer struct {
+	traceIDKey  string
+	spanIDKey   string
+	traceURLKey string
+	urlTemplate string
+}
+
+// NewTraceCapturer creates a new TraceCapturer.
+func NewTraceCapturer(opts ...TraceOption) *TraceCapturer {
+	t := &TraceCapturer{
+		traceIDKey:  "trace_id",
+		spanIDKey:   "span_id",
+		traceURLKey: "trace_url",
+	}
+	for _, opt := range opts {
+		opt.Apply(t)
+	}
+	return t
+}
+
+// CaptureTrace implements maleo.TraceCapturer.
+//
+// Returns nil if there is no valid span context in ctx. The trace url is included if WithTraceURL is set.
+func (t *TraceCapturer) CaptureTrace(ctx context.Context) []maleo.KVString {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return nil
+	}
+	out := make([]maleo.KVString, 0, 3)
+	out = append(out,
+		maleo.KVString{Key: t.traceIDKey, Value: sc.TraceID().String()},
+		maleo.KVString{Key: t.spanIDKey, Value: sc.SpanID().String()},
+	)
+	if url := t.traceURL(sc); url != "" {
+		out = append(out, maleo.KVString{Key: t.traceURLKey, Value: url})
+	}
+	return out
+}
+
+// TraceURL returns the link to the trace of the span in ctx.
+//
+// Returns empty string if there is no valid span context in ctx or WithTraceURL is not set.
+func (t *TraceCapturer) TraceURL(ctx context.Context) string {
+	return t.traceURL(trace.SpanContextFromContext(ctx))
+}
+
+func (t *TraceCapturer) traceURL(sc trace.SpanContext) string {
+	if t.urlTemplate == "" || !sc.IsValid() {
+		return ""
+	}
+	return strings.NewReplacer(
+		"{trace_id}", sc.TraceID().String(),
+		"{span_id}", sc.SpanID().String(),
+	).Replace(t.urlTemplate)
+}
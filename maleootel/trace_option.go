@@ -0,0 +1,42 @@
+package maleootel
+
+type TraceOption interface {
+	Apply(*TraceCapturer)
+}
+
+type TraceOptionFunc func(*TraceCapturer)
+
+func (f TraceOptionFunc) Apply(t *TraceCapturer) {
+	f(t)
+}
+
+// WithTraceURL sets the template of the link to the trace in your tracing backend.
+// The link is added to the captured trace, e.g. in Discord embeds, and to HTTP error bodies by ErrorBodyTransformer.
+//
+// The placeholders {trace_id} and {span_id} are replaced with the ids from the span context.
+//
+// Example:
+//
+//	maleootel.WithTraceURL("https://jaeger.example.com/trace/{trace_id}")
+func WithTraceURL(template string) TraceOption {
+	return TraceOptionFunc(func(t *TraceCapturer) {
+		t.urlTemplate = template
+	})
+}
+
+// WithTraceKeys overrides the keys of the captured trace. Empty values are ignored.
+//
+// Defaults to "trace_id", "span_id", and "trace_url".
+func WithTraceKeys(traceID, spanID, traceURL string) TraceOption {
+	return TraceOptionFunc(func(t *TraceCapturer) {
+		if traceID != "" {
+			t.traceIDKey = traceID
+		}
+		if spanID != "" {
+			t.spanIDKey = spanID
+		}
+		if traceURL != "" {
+			t.traceURLKey = traceURL
+		}
+	})
+}
@@ -0,0 +1,76 @@
+package maleootel
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/tigorlazuardi/maleo"
+)
+
+type simpleErrorTransformer struct{}
+
+func (simpleErrorTransformer) ErrorBodyTransform(_ context.Context, err error) any {
+	return map[string]any{"error": err.Error()}
+}
+
+func TestTraceCapturer(t *testing.T) {
+	_, provider := newTestTracer()
+	capturer := NewTraceCapturer(WithTraceURL("https://jaeger.example.com/trace/{trace_id}?span={span_id}"))
+
+	if got := capturer.CaptureTrace(context.Background()); got != nil {
+		t.Errorf("CaptureTrace() without span = %v, want nil", got)
+	}
+
+	ctx, span := provider.Tracer("test").Start(context.Background(), "operation")
+	defer span.End()
+	sc := span.SpanContext()
+	wantURL := "https://jaeger.example.com/trace/" + sc.TraceID().String() + "?span=" + sc.SpanID().String()
+	want := []maleo.KVString{
+		{Key: "trace_id", Value: sc.TraceID().String()},
+		{Key: "span_id", Value: sc.SpanID().String()},
+		{Key: "trace_url", Value: wantURL},
+	}
+	got := capturer.CaptureTrace(ctx)
+	if len(got) != len(want) {
+		t.Fatalf("CaptureTrace() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("CaptureTrace()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+	if got := capturer.TraceURL(ctx); got != wantURL {
+		t.Errorf("TraceURL() = %v, want %v", got, wantURL)
+	}
+
+	keyed := NewTraceCapturer(WithTraceKeys("traceId", "", ""))
+	if got := keyed.CaptureTrace(ctx); len(got) != 2 || got[0].Key != "traceId" || got[1].Key != "span_id" {
+		t.Errorf("CaptureTrace() with custom keys = %v", got)
+	}
+}
+
+func TestTraceErrorBodyTransformer(t *testing.T) {
+	_, provider := newTestTracer()
+	capturer := NewTraceCapturer(WithTraceURL("https://jaeger.example.com/trace/{trace_id}"))
+	transformer := NewErrorBodyTransformer(simpleErrorTransformer{}, capturer)
+
+	body := transformer.ErrorBodyTransform(context.Background(), errors.New("boom"))
+	if m := body.(map[string]any); len(m) != 1 {
+		t.Errorf("body without span = %v, want only the error", m)
+	}
+
+	ctx, span := provider.Tracer("test").Start(context.Background(), "operation")
+	defer span.End()
+	m := transformer.ErrorBodyTransform(ctx, errors.New("boom")).(map[string]any)
+	traceID := span.SpanContext().TraceID().String()
+	if m["trace_id"] != traceID {
+		t.Errorf("trace_id = %v, want %v", m["trace_id"], traceID)
+	}
+	if m["trace_url"] != "https://jaeger.example.com/trace/"+traceID {
+		t.Errorf("trace_url = %v, want %v", m["trace_url"], "https://jaeger.example.com/trace/"+traceID)
+	}
+	if m["error"] != "boom" {
+		t.Errorf("error = %v, want %v", m["error"], "boom")
+	}
+}
@@ -0,0 +1,5 @@
+module github.com/tigorlazuardi/maleo/maleoslog
+
+go 1.21
+
+require github.com/tigorlazuardi/maleo v0.5.0
@@ -0,0 +1,159 @@
+package maleoslog
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/tigorlazuardi/maleo"
+)
+
+var _ slog.Handler = (*Handler)(nil)
+
+// Handler is a slog.Handler that turns slog records into maleo Entries and logs them using Maleo instance.
+//
+// This allows third-party libraries that log via slog to reach the Maleo instance.
+type Handler struct {
+	maleo *maleo.Maleo
+	level slog.Leveler
+	goas  []groupOrAttrs
+}
+
+// groupOrAttrs holds either a group name or a list of attributes, in the order of WithGroup and WithAttrs calls.
+type groupOrAttrs struct {
+	group string
+	attrs []slog.Attr
+}
+
+// NewHandler creates a new slog.Handler that writes records as maleo Entries to given Maleo instance.
+//
+// If m is nil, the Global Maleo instance at the time of the log call will be used,
+// which in turn will use the Maleo instance attached to the context if there is any.
+//
+// The record's message, level, time, and source location are used as the Entry's message, level, time, and caller.
+// Attributes are turned into maleo.Fields and set as the Entry's Context. Groups become nested maleo.Fields.
+//
+// Do not use this handler for the *slog.Logger that the Maleo instance is writing to using maleoslog.Logger,
+// as it will cause infinite recursion.
+func NewHandler(m *maleo.Maleo, opts ...HandlerOption) *Handler {
+	h := &Handler{
+		maleo: m,
+		level: slog.LevelInfo,
+	}
+	for _, opt := range opts {
+		opt.Apply(h)
+	}
+	return h
+}
+
+// Enabled implements slog.Handler.
+func (h *Handler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+// Handle implements slog.Handler.
+func (h *Handler) Handle(ctx context.Context, record slog.Record) error {
+	m := h.maleo
+	if m == nil {
+		m = maleo.Global()
+	}
+	builder := m.NewEntry(record.Message).Level(TranslateSlogLevel(record.Level))
+	if !record.Time.IsZero() {
+		builder.Time(record.Time)
+	}
+	if record.PC != 0 {
+		builder.Caller(maleo.CallerFromPC(record.PC))
+	}
+	if fields := h.fields(record); len(fields) > 0 {
+		builder.Context(fields)
+	}
+	// Records at LevelFatal and above are only logged. slog callers do not expect logging to exit the program.
+	builder.Log(maleo.ContextWithoutTermination(ctx))
+	return nil
+}
+
+// WithAttrs implements slog.Handler.
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	return h.withGroupOrAttrs(groupOrAttrs{attrs: attrs})
+}
+
+// WithGroup implements slog.Handler.
+func (h *Handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return h.withGroupOrAttrs(groupOrAttrs{group: name})
+}
+
+func (h *Handler) withGroupOrAttrs(goa groupOrAttrs) *Handler {
+	h2 := *h
+	h2.goas = make([]groupOrAttrs, len(h.goas)+1)
+	copy(h2.goas, h.goas)
+	h2.goas[len(h2.goas)-1] = goa
+	return &h2
+}
+
+func (h *Handler) fields(record slog.Record) maleo.Fields {
+	root := maleo.Fields{}
+	current := root
+	for _, goa := range h.goas {
+		if goa.group != "" {
+			next := maleo.Fields{}
+			current[goa.group] = next
+			current = next
+			continue
+		}
+		for _, attr := range goa.attrs {
+			addAttr(current, attr)
+		}
+	}
+	record.Attrs(func(attr slog.Attr) bool {
+		addAttr(current, attr)
+		return true
+	})
+	pruneEmptyGroups(root)
+	return root
+}
+
+func addAttr(f maleo.Fields, attr slog.Attr) {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return
+	}
+	if attr.Value.Kind() != slog.KindGroup {
+		f[attr.Key] = attr.Value.Any()
+		return
+	}
+	attrs := attr.Value.Group()
+	if len(attrs) == 0 {
+		return
+	}
+	// Groups with empty key are inlined per slog.Handler contract.
+	if attr.Key == "" {
+		for _, a := range attrs {
+			addAttr(f, a)
+		}
+		return
+	}
+	group := maleo.Fields{}
+	for _, a := range attrs {
+		addAttr(group, a)
+	}
+	f[attr.Key] = group
+}
+
+// pruneEmptyGroups removes groups that end up having no values, as required by slog.Handler contract.
+func pruneEmptyGroups(f maleo.Fields) {
+	for k, v := range f {
+		group, ok := v.(maleo.Fields)
+		if !ok {
+			continue
+		}
+		pruneEmptyGroups(group)
+		if len(group) == 0 {
+			delete(f, k)
+		}
+	}
+}
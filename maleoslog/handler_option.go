@@ -0,0 +1,22 @@
+package maleoslog
+
+import "log/slog"
+
+type HandlerOption interface {
+	Apply(*Handler)
+}
+
+type HandlerOptionFunc func(*Handler)
+
+func (f HandlerOptionFunc) Apply(h *Handler) {
+	f(h)
+}
+
+// WithLevel sets the minimum level of records the Handler will process. Defaults to slog.LevelInfo.
+//
+// Use *slog.LevelVar to change the level at runtime.
+func WithLevel(level slog.Leveler) HandlerOption {
+	return HandlerOptionFunc(func(h *Handler) {
+		h.level = level
+	})
+}
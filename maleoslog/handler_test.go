@@ -0,0 +1,107 @@
+package maleoslog
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/tigorlazuardi/maleo"
+)
+
+func TestHandler(t *testing.T) {
+	m, logger := maleo.NewTestingMaleo()
+	log := slog.New(NewHandler(m, WithLevel(slog.LevelDebug)))
+	log = log.With("request_id", "abc").WithGroup("db").With("table", "users")
+	log.WarnContext(context.Background(), "slow query", "duration_ms", 1500, slog.Group("empty"))
+
+	var got map[string]any
+	if err := json.Unmarshal(logger.Bytes(), &got); err != nil {
+		t.Fatalf("failed to unmarshal log output: %v: %s", err, logger.String())
+	}
+	if got["message"] != "slow query" {
+		t.Errorf("message = %v, want %v", got["message"], "slow query")
+	}
+	if got["level"] != "warn" {
+		t.Errorf("level = %v, want %v", got["level"], "warn")
+	}
+	caller, _ := got["caller"].(map[string]any)
+	if caller["function"] != "maleoslog.TestHandler" {
+		t.Errorf("caller.function = %v, want %v", caller["function"], "maleoslog.TestHandler")
+	}
+	ctx, _ := got["context"].(map[string]any)
+	if ctx["request_id"] != "abc" {
+		t.Errorf("context.request_id = %v, want %v", ctx["request_id"], "abc")
+	}
+	db, _ := ctx["db"].(map[string]any)
+	if db["table"] != "users" {
+		t.Errorf("context.db.table = %v, want %v", db["table"], "users")
+	}
+	if db["duration_ms"] != float64(1500) {
+		t.Errorf("context.db.duration_ms = %v, want %v", db["duration_ms"], 1500)
+	}
+	if _, ok := db["empty"]; ok {
+		t.Errorf("empty group should be omitted")
+	}
+}
+
+func TestHandler_Enabled(t *testing.T) {
+	m, logger := maleo.NewTestingMaleo()
+	level := new(slog.LevelVar)
+	log := slog.New(NewHandler(m, WithLevel(level)))
+	log.Debug("debug")
+	if len(logger.Bytes()) != 0 {
+		t.Fatalf("debug record should not be handled, got %s", logger.String())
+	}
+	level.Set(slog.LevelDebug)
+	log.Debug("debug")
+	if len(logger.Bytes()) == 0 {
+		t.Fatalf("debug record should be handled after level change")
+	}
+}
+
+func TestHandler_NoTermination(t *testing.T) {
+	exited := false
+	logger := maleo.NewTestingJSONLogger()
+	m := maleo.New(maleo.Service{Name: "test"}, maleo.Option.Init().
+		Logger(logger).
+		ExitFunc(func(int) { exited = true }),
+	)
+	log := slog.New(NewHandler(m))
+	log.Log(context.Background(), LevelFatal, "fatal record")
+	log.Log(context.Background(), LevelPanic, "panic record")
+	if exited {
+		t.Error("fatal record must not exit the program")
+	}
+	if out := logger.String(); !strings.Contains(out, "fatal record") || !strings.Contains(out, "panic record") {
+		t.Errorf("expected both records to be logged, got %s", out)
+	}
+}
+
+func TestTranslateSlogLevel(t *testing.T) {
+	tests := []struct {
+		in   slog.Level
+		want maleo.Level
+	}{
+		{slog.LevelDebug - 4, maleo.DebugLevel},
+		{slog.LevelDebug, maleo.DebugLevel},
+		{slog.LevelInfo, maleo.InfoLevel},
+		{slog.LevelInfo + 2, maleo.InfoLevel},
+		{slog.LevelWarn, maleo.WarnLevel},
+		{slog.LevelError, maleo.ErrorLevel},
+		{LevelFatal, maleo.FatalLevel},
+		{LevelPanic, maleo.PanicLevel},
+		{LevelPanic + 4, maleo.PanicLevel},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in.String(), func(t *testing.T) {
+			if got := TranslateSlogLevel(tt.in); got != tt.want {
+				t.Errorf("TranslateSlogLevel() = %v, want %v", got, tt.want)
+			}
+			if got := TranslateSlogLevel(TranslateLevel(tt.want)); got != tt.want {
+				t.Errorf("round trip = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package maleoslog
+
+import (
+	"log/slog"
+
+	"github.com/tigorlazuardi/maleo"
+)
+
+const (
+	// LevelFatal is the slog.Level maleo.FatalLevel is translated to.
+	LevelFatal = slog.LevelError + 4
+	// LevelPanic is the slog.Level maleo.PanicLevel is translated to.
+	LevelPanic = slog.LevelError + 8
+)
+
+// TranslateLevel translates maleo.Level to slog.Level.
+func TranslateLevel(lvl maleo.Level) slog.Level {
+	switch lvl {
+	case maleo.DebugLevel:
+		return slog.LevelDebug
+	case maleo.InfoLevel:
+		return slog.LevelInfo
+	case maleo.WarnLevel:
+		return slog.LevelWarn
+	case maleo.ErrorLevel:
+		return slog.LevelError
+	case maleo.FatalLevel:
+		return LevelFatal
+	case maleo.PanicLevel:
+		return LevelPanic
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// TranslateSlogLevel translates slog.Level to maleo.Level.
+//
+// Levels between the slog predefined levels are rounded down, e.g. slog.LevelInfo+2 is translated to maleo.InfoLevel.
+func TranslateSlogLevel(lvl slog.Level) maleo.Level {
+	switch {
+	case lvl >= LevelPanic:
+		return maleo.PanicLevel
+	case lvl >= LevelFatal:
+		return maleo.FatalLevel
+	case lvl >= slog.LevelError:
+		return maleo.ErrorLevel
+	case lvl >= slog.LevelWarn:
+		return maleo.WarnLevel
+	case lvl >= slog.LevelInfo:
+		return maleo.InfoLevel
+	default:
+		return maleo.DebugLevel
+	}
+}
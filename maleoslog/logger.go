@@ -0,0 +1,209 @@
+package maleoslog
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/tigorlazuardi/maleo"
+)
+
+var _ maleo.Logger = (*Logger)(nil)
+
+type TraceCapturer interface {
+	CaptureTrace(ctx context.Context) []slog.Attr
+}
+
+type TraceCapturerFunc func(ctx context.Context) []slog.Attr
+
+func (f TraceCapturerFunc) CaptureTrace(ctx context.Context) []slog.Attr {
+	return f(ctx)
+}
+
+// TraceCapturerFromMaleo adapts a maleo.TraceCapturer, e.g. maleootel.TraceCapturer, to a TraceCapturer.
+func TraceCapturerFromMaleo(capturer maleo.TraceCapturer) TraceCapturer {
+	return TraceCapturerFunc(func(ctx context.Context) []slog.Attr {
+		kvs := capturer.CaptureTrace(ctx)
+		if len(kvs) == 0 {
+			return nil
+		}
+		out := make([]slog.Attr, 0, len(kvs))
+		for _, kv := range kvs {
+			out = append(out, slog.String(kv.Key, kv.Value))
+		}
+		return out
+	})
+}
+
+type DisabledField uint16
+
+func (d DisabledField) Has(flag DisabledField) bool {
+	return d&flag != 0
+}
+
+func (d *DisabledField) Set(flag DisabledField) {
+	*d |= flag
+}
+
+const (
+	DisableTime DisabledField = 1 << iota
+	DisableCaller
+	DisableTrace
+	DisableService
+	DisableKey
+	DisableCode
+	DisableContext
+	DisableError
+	DisableStack
+
+	DisableNothing DisabledField = 0
+	DisableAll     DisabledField = ^DisableNothing
+)
+
+// Logger is a maleo.Logger implementation that writes Entry and Error to *slog.Logger.
+type Logger struct {
+	*slog.Logger
+	tracer TraceCapturer
+	flag   DisabledField
+}
+
+// New creates a new maleo.Logger that writes to given *slog.Logger.
+//
+// The time and source location of the slog.Record are taken from the Entry or Error, not from when and where
+// the Logger is called.
+//
+// Do not use the *slog.Logger whose handler is created by maleoslog.NewHandler that points to the same Maleo instance
+// this Logger is registered to, as it will cause infinite recursion.
+func New(l *slog.Logger, opts ...LoggerOption) *Logger {
+	logger := &Logger{
+		Logger: l,
+		tracer: TraceCapturerFunc(func(ctx context.Context) []slog.Attr { return nil }),
+		flag:   DisableNothing,
+	}
+	for _, opt := range opts {
+		opt.Apply(logger)
+	}
+	return logger
+}
+
+func (l *Logger) SetTraceCapturer(capturer TraceCapturer) {
+	l.tracer = capturer
+}
+
+// SetDisabledFieldFlag sets the field from Maleo's Entry or Maleo's Error
+// from being added to the slog record attributes.
+//
+// e.g. SetDisableField(maleoslog.DisableTime | maleoslog.DisableCaller)
+// will prevent data of Error.Time() or Error.Caller() from being printed.
+func (l *Logger) SetDisabledFieldFlag(flag DisabledField) {
+	l.flag = flag
+}
+
+func (l *Logger) Log(ctx context.Context, entry maleo.Entry) {
+	level := TranslateLevel(entry.Level())
+	if !l.Logger.Enabled(ctx, level) {
+		return
+	}
+	record := l.newRecord(entry.Time(), level, entry.Message(), entry.Caller())
+	attrs := make([]slog.Attr, 0, 8)
+	if !l.flag.Has(DisableTrace) {
+		attrs = append(attrs, l.tracer.CaptureTrace(ctx)...)
+	}
+	if !l.flag.Has(DisableService) {
+		attrs = append(attrs, slog.Any("service", entry.Service()))
+	}
+	if ch, ok := entry.(maleo.ComponentHint); ok {
+		if component := ch.Component(); component != "" {
+			attrs = append(attrs, slog.String("component", component))
+		}
+	}
+	if !l.flag.Has(DisableKey) {
+		if key := entry.Key(); key != "" {
+			attrs = append(attrs, slog.String("key", key))
+		}
+	}
+	if !l.flag.Has(DisableCode) {
+		if code := entry.Code(); code != 0 {
+			attrs = append(attrs, slog.Int("code", code))
+		}
+	}
+	if !l.flag.Has(DisableCaller) {
+		attrs = append(attrs, slog.Any("caller", entry.Caller()))
+	}
+	if !l.flag.Has(DisableContext) {
+		if cf, ok := entry.(maleo.ContextFieldsHint); ok {
+			if f := cf.ContextFields(); len(f) > 0 {
+				attrs = append(attrs, slog.Any("context_fields", f))
+			}
+		}
+		if data := entry.Context(); len(data) > 0 {
+			attrs = append(attrs, slog.Attr{Key: "context", Value: maleo.ContextLogValue(data)})
+		}
+	}
+	record.AddAttrs(attrs...)
+	_ = l.Logger.Handler().Handle(ctx, record)
+}
+
+func (l *Logger) LogError(ctx context.Context, err maleo.Error) {
+	level := TranslateLevel(err.Level())
+	if !l.Logger.Enabled(ctx, level) {
+		return
+	}
+	record := l.newRecord(err.Time(), level, err.Message(), err.Caller())
+	attrs := make([]slog.Attr, 0, 8)
+	if !l.flag.Has(DisableTrace) {
+		attrs = append(attrs, l.tracer.CaptureTrace(ctx)...)
+	}
+	if !l.flag.Has(DisableService) {
+		attrs = append(attrs, slog.Any("service", err.Service()))
+	}
+	if ch, ok := err.(maleo.ComponentHint); ok {
+		if component := ch.Component(); component != "" {
+			attrs = append(attrs, slog.String("component", component))
+		}
+	}
+	if !l.flag.Has(DisableCode) {
+		attrs = append(attrs, slog.Int("code", err.Code()))
+	}
+	if !l.flag.Has(DisableCaller) {
+		attrs = append(attrs, slog.Any("caller", err.Caller()))
+	}
+	if !l.flag.Has(DisableStack) {
+		if sh, ok := err.(maleo.StackHint); ok {
+			if frames := sh.Frames(); len(frames) > 0 {
+				attrs = append(attrs, slog.Any("stack", frames))
+			}
+		}
+	}
+	if !l.flag.Has(DisableKey) {
+		if key := err.Key(); key != "" {
+			attrs = append(attrs, slog.String("key", key))
+		}
+	}
+	if !l.flag.Has(DisableContext) {
+		if cf, ok := err.(maleo.ContextFieldsHint); ok {
+			if f := cf.ContextFields(); len(f) > 0 {
+				attrs = append(attrs, slog.Any("context_fields", f))
+			}
+		}
+		if data := err.Context(); len(data) > 0 {
+			attrs = append(attrs, slog.Attr{Key: "context", Value: maleo.ContextLogValue(data)})
+		}
+	}
+	if !l.flag.Has(DisableError) {
+		attrs = append(attrs, slog.Attr{Key: "error", Value: maleo.ErrorLogValue(err.Unwrap())})
+	}
+	record.AddAttrs(attrs...)
+	_ = l.Logger.Handler().Handle(ctx, record)
+}
+
+func (l *Logger) newRecord(t time.Time, level slog.Level, msg string, caller maleo.Caller) slog.Record {
+	if l.flag.Has(DisableTime) {
+		t = time.Time{}
+	}
+	var pc uintptr
+	if caller != nil && !l.flag.Has(DisableCaller) {
+		pc = caller.PC()
+	}
+	return slog.NewRecord(t, level, msg, pc)
+}
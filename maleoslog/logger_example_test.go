@@ -0,0 +1,20 @@
+package maleoslog_test
+
+import (
+	"log/slog"
+	"os"
+
+	"github.com/tigorlazuardi/maleo"
+	"github.com/tigorlazuardi/maleo/maleoslog"
+)
+
+func ExampleNew() {
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, nil))
+	mlog := maleoslog.New(logger)
+	maleo.Global().SetLogger(mlog)
+}
+
+func ExampleNewHandler() {
+	// Third-party libraries that log via slog.Default() will have their records sent to maleo's Global instance.
+	slog.SetDefault(slog.New(maleoslog.NewHandler(nil)))
+}
@@ -0,0 +1,137 @@
+package maleoslog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/tigorlazuardi/maleo"
+)
+
+func newTestLogger(flag DisabledField) (*maleo.Maleo, *bytes.Buffer) {
+	buf := new(bytes.Buffer)
+	handler := slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug})
+	logger := New(slog.New(handler), WithDisableFieldFlag(flag))
+	m := maleo.New(maleo.Service{Name: "test", Environment: "test"}, maleo.Option.Init().Logger(logger))
+	return m, buf
+}
+
+func TestLogger_Log(t *testing.T) {
+	m, buf := newTestLogger(DisableTime)
+	m.NewEntry("hello %s", "world").
+		Code(201).
+		Key("greeting").
+		Context(maleo.F{"foo": "bar", "nested": maleo.F{"baz": 1}}).
+		Log(context.Background())
+
+	var got map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("failed to unmarshal log output: %v: %s", err, buf.String())
+	}
+	if _, ok := got["time"]; ok {
+		t.Errorf("time should not be logged when DisableTime is set")
+	}
+	if got["msg"] != "hello world" {
+		t.Errorf("msg = %v, want %v", got["msg"], "hello world")
+	}
+	if got["level"] != "INFO" {
+		t.Errorf("level = %v, want %v", got["level"], "INFO")
+	}
+	if got["code"] != float64(201) {
+		t.Errorf("code = %v, want %v", got["code"], 201)
+	}
+	if got["key"] != "greeting" {
+		t.Errorf("key = %v, want %v", got["key"], "greeting")
+	}
+	service, _ := got["service"].(map[string]any)
+	if service["name"] != "test" || service["environment"] != "test" {
+		t.Errorf("service = %v, want name and environment of test", service)
+	}
+	ctx, _ := got["context"].(map[string]any)
+	if ctx["foo"] != "bar" {
+		t.Errorf("context.foo = %v, want %v", ctx["foo"], "bar")
+	}
+	nested, _ := ctx["nested"].(map[string]any)
+	if nested["baz"] != float64(1) {
+		t.Errorf("context.nested.baz = %v, want %v", nested["baz"], 1)
+	}
+	caller, _ := got["caller"].(map[string]any)
+	if caller["function"] != "maleoslog.TestLogger_Log" {
+		t.Errorf("caller.function = %v, want %v", caller["function"], "maleoslog.TestLogger_Log")
+	}
+}
+
+func TestLogger_LogError(t *testing.T) {
+	m, buf := newTestLogger(DisableTime | DisableService)
+	origin := m.Bail("inner").Code(400).Context("user_id", 5).Freeze()
+	_ = m.Wrap(origin, "outer").Level(maleo.WarnLevel).Log(context.Background())
+
+	var got map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("failed to unmarshal log output: %v: %s", err, buf.String())
+	}
+	if _, ok := got["service"]; ok {
+		t.Errorf("service should not be logged when DisableService is set")
+	}
+	if got["level"] != "WARN" {
+		t.Errorf("level = %v, want %v", got["level"], "WARN")
+	}
+	if got["msg"] != "outer" {
+		t.Errorf("msg = %v, want %v", got["msg"], "outer")
+	}
+	if got["code"] != float64(400) {
+		t.Errorf("code = %v, want %v", got["code"], 400)
+	}
+	inner, _ := got["error"].(map[string]any)
+	if inner["message"] != "inner" {
+		t.Errorf("error.message = %v, want %v", inner["message"], "inner")
+	}
+	ctx, _ := inner["context"].(map[string]any)
+	if ctx["user_id"] != float64(5) {
+		t.Errorf("error.context.user_id = %v, want %v", ctx["user_id"], 5)
+	}
+	origin2, _ := inner["error"].(map[string]any)
+	if origin2["summary"] != "inner" {
+		t.Errorf("error.error.summary = %v, want %v", origin2["summary"], "inner")
+	}
+}
+
+func TestLogger_Level(t *testing.T) {
+	buf := new(bytes.Buffer)
+	handler := slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelWarn})
+	m := maleo.New(maleo.Service{}, maleo.Option.Init().Logger(New(slog.New(handler))).ExitFunc(func(int) {}))
+	m.NewEntry("info").Log(context.Background())
+	if buf.Len() != 0 {
+		t.Errorf("info entry should not be logged, got %s", buf.String())
+	}
+	_ = m.Wrap(errors.New("boom")).Level(maleo.FatalLevel).Log(context.Background())
+	var got map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("failed to unmarshal log output: %v: %s", err, buf.String())
+	}
+	if got["level"] != "ERROR+4" {
+		t.Errorf("level = %v, want %v", got["level"], "ERROR+4")
+	}
+}
+
+func TestLogger_DisableCaller(t *testing.T) {
+	buf := new(bytes.Buffer)
+	handler := slog.NewJSONHandler(buf, &slog.HandlerOptions{AddSource: true})
+	logger := New(slog.New(handler), WithDisableFieldFlag(DisableCaller))
+	m := maleo.New(maleo.Service{Name: "test"}, maleo.Option.Init().Logger(logger))
+	m.NewEntry("hello").Log(context.Background())
+
+	var got map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("failed to unmarshal log output: %v: %s", err, buf.String())
+	}
+	if _, ok := got["caller"]; ok {
+		t.Errorf("caller should not be logged when DisableCaller is set")
+	}
+	if _, ok := got[slog.SourceKey]; ok {
+		t.Errorf("source should not be logged when DisableCaller is set, got %v", got[slog.SourceKey])
+	}
+}
@@ -0,0 +1,47 @@
+package maleotest
+
+import "testing"
+
+// AssertNotified fails the test if no notification matches the query. Returns the matching notifications.
+//
+//	maleotest.AssertNotified(t, messenger.Entries().Level(maleo.ErrorLevel).Code(500))
+func AssertNotified(t testing.TB, entries Entries) Entries {
+	t.Helper()
+	assertFound(t, entries)
+	return entries
+}
+
+// AssertNotNotified fails the test if any notification matches the query.
+func AssertNotNotified(t testing.TB, entries Entries) {
+	t.Helper()
+	assertNotFound(t, entries)
+}
+
+// AssertLogged fails the test if no log entry matches the query. Returns the matching log entries.
+//
+//	maleotest.AssertLogged(t, logger.Entries().MessageContains("timeout"))
+func AssertLogged(t testing.TB, entries Entries) Entries {
+	t.Helper()
+	assertFound(t, entries)
+	return entries
+}
+
+// AssertNotLogged fails the test if any log entry matches the query.
+func AssertNotLogged(t testing.TB, entries Entries) {
+	t.Helper()
+	assertNotFound(t, entries)
+}
+
+func assertFound(t testing.TB, entries Entries) {
+	t.Helper()
+	if entries.Empty() {
+		t.Errorf("expected %s, found none\n%s", entries, entries.describe())
+	}
+}
+
+func assertNotFound(t testing.TB, entries Entries) {
+	t.Helper()
+	if !entries.Empty() {
+		t.Errorf("expected no %s, found %d\n%s", entries, entries.Len(), entries.describe())
+	}
+}
@@ -0,0 +1,155 @@
+package maleotest
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tigorlazuardi/maleo"
+)
+
+/*
+Entries is a query over the recorded entries. Every filter returns a new Entries with the matching entries,
+so filters can be chained:
+
+	logger.Entries().Level(maleo.ErrorLevel).MessageContains("timeout").Code(500)
+
+Entries remembers the filters applied, which are used to describe the query when an assertion fails.
+*/
+type Entries struct {
+	kind    string
+	plural  string
+	all     []Entry
+	matches []Entry
+	query   []string
+}
+
+func newEntries(kind, plural string, entries []Entry) Entries {
+	return Entries{kind: kind, plural: plural, all: entries, matches: entries}
+}
+
+// Where keeps the entries for which match returns true. desc describes the filter in assertion failures.
+func (e Entries) Where(desc string, match func(Entry) bool) Entries {
+	out := Entries{kind: e.kind, plural: e.plural, all: e.all, query: append(e.query[:len(e.query):len(e.query)], desc)}
+	for _, entry := range e.matches {
+		if match(entry) {
+			out.matches = append(out.matches, entry)
+		}
+	}
+	return out
+}
+
+// Level keeps the entries with the given level.
+func (e Entries) Level(lvl maleo.Level) Entries {
+	return e.Where(fmt.Sprintf("Level(%s)", lvl), func(entry Entry) bool {
+		return entry.Level == lvl
+	})
+}
+
+// MinLevel keeps the entries with level at least as severe as lvl.
+func (e Entries) MinLevel(lvl maleo.Level) Entries {
+	return e.Where(fmt.Sprintf("MinLevel(%s)", lvl), func(entry Entry) bool {
+		return lvl.Enabled(entry.Level)
+	})
+}
+
+// Message keeps the entries with the exact message.
+func (e Entries) Message(msg string) Entries {
+	return e.Where(fmt.Sprintf("Message(%q)", msg), func(entry Entry) bool {
+		return entry.Message == msg
+	})
+}
+
+// MessageContains keeps the entries with messages containing s.
+func (e Entries) MessageContains(s string) Entries {
+	return e.Where(fmt.Sprintf("MessageContains(%q)", s), func(entry Entry) bool {
+		return strings.Contains(entry.Message, s)
+	})
+}
+
+// Code keeps the entries with the given code.
+func (e Entries) Code(code int) Entries {
+	return e.Where(fmt.Sprintf("Code(%d)", code), func(entry Entry) bool {
+		return entry.Code == code
+	})
+}
+
+// Key keeps the entries with the given key.
+func (e Entries) Key(key string) Entries {
+	return e.Where(fmt.Sprintf("Key(%q)", key), func(entry Entry) bool {
+		return entry.Key == key
+	})
+}
+
+// Component keeps the entries from the given component.
+func (e Entries) Component(name string) Entries {
+	return e.Where(fmt.Sprintf("Component(%q)", name), func(entry Entry) bool {
+		return entry.Component == name
+	})
+}
+
+// HasError keeps the entries with an error.
+func (e Entries) HasError() Entries {
+	return e.Where("HasError()", func(entry Entry) bool {
+		return entry.Err != nil
+	})
+}
+
+// ErrorContains keeps the entries with errors whose message contains s.
+func (e Entries) ErrorContains(s string) Entries {
+	return e.Where(fmt.Sprintf("ErrorContains(%q)", s), func(entry Entry) bool {
+		return entry.Err != nil && strings.Contains(entry.Err.Error(), s)
+	})
+}
+
+// All returns the matching entries.
+func (e Entries) All() []Entry {
+	return e.matches
+}
+
+// Len returns the number of matching entries.
+func (e Entries) Len() int {
+	return len(e.matches)
+}
+
+// Empty returns true if there are no matching entries.
+func (e Entries) Empty() bool {
+	return len(e.matches) == 0
+}
+
+// First returns the first matching entry. Returns false if there are no matching entries.
+func (e Entries) First() (Entry, bool) {
+	if len(e.matches) == 0 {
+		return Entry{}, false
+	}
+	return e.matches[0], true
+}
+
+// Messages returns the messages of the matching entries.
+func (e Entries) Messages() []string {
+	out := make([]string, 0, len(e.matches))
+	for _, entry := range e.matches {
+		out = append(out, entry.Message)
+	}
+	return out
+}
+
+// String describes the query.
+func (e Entries) String() string {
+	if len(e.query) == 0 {
+		return e.plural
+	}
+	return e.kind + " matching " + strings.Join(e.query, ".")
+}
+
+// describe lists the recorded entries, which helps to find out why a query does not match.
+func (e Entries) describe() string {
+	s := &strings.Builder{}
+	_, _ = fmt.Fprintf(s, "recorded %s: %d", e.plural, len(e.all))
+	for _, entry := range e.all {
+		_, _ = fmt.Fprintf(s, "\n\t[%s] code=%d key=%q message=%q", entry.Level, entry.Code, entry.Key, entry.Message)
+		if entry.Err != nil {
+			_, _ = fmt.Fprintf(s, " error=%q", entry.Err.Error())
+		}
+	}
+	return s.String()
+}
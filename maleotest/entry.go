@@ -0,0 +1,62 @@
+package maleotest
+
+import (
+	"time"
+
+	"github.com/tigorlazuardi/maleo"
+)
+
+// Entry is a log entry or a notification recorded by Logger or Messenger.
+type Entry struct {
+	Message   string
+	Level     maleo.Level
+	Code      int
+	HTTPCode  int
+	Key       string
+	Component string
+	Caller    maleo.Caller
+	Context   []any
+	Service   maleo.Service
+	Time      time.Time
+	// Err is the logged or notified error. Nil for entries.
+	Err error
+	// MessageContext is the notified message. Nil for log entries.
+	MessageContext maleo.MessageContext
+}
+
+type entryLike interface {
+	maleo.CallerHint
+	maleo.CodeHint
+	maleo.ContextHint
+	maleo.HTTPCodeHint
+	maleo.KeyHint
+	maleo.LevelHint
+	maleo.MessageHint
+	maleo.ServiceHint
+	maleo.TimeHint
+}
+
+func newEntry(v entryLike) Entry {
+	e := Entry{
+		Message:  v.Message(),
+		Level:    v.Level(),
+		Code:     v.Code(),
+		HTTPCode: v.HTTPCode(),
+		Key:      v.Key(),
+		Caller:   v.Caller(),
+		Context:  v.Context(),
+		Service:  v.Service(),
+		Time:     v.Time(),
+	}
+	if ch, ok := v.(maleo.ComponentHint); ok {
+		e.Component = ch.Component()
+	}
+	return e
+}
+
+func entryFromMessage(msg maleo.MessageContext) Entry {
+	e := newEntry(msg)
+	e.Err = msg.Err()
+	e.MessageContext = msg
+	return e
+}
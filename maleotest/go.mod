@@ -0,0 +1,5 @@
+module github.com/tigorlazuardi/maleo/maleotest
+
+go 1.19
+
+require github.com/tigorlazuardi/maleo v0.5.0
@@ -0,0 +1,55 @@
+package maleotest
+
+import (
+	"context"
+	"sync"
+
+	"github.com/tigorlazuardi/maleo"
+)
+
+var _ maleo.Logger = (*Logger)(nil)
+
+// Logger is a maleo.Logger that keeps the log entries in memory.
+type Logger struct {
+	mu      sync.Mutex
+	entries []Entry
+}
+
+// NewLogger returns an empty Logger.
+func NewLogger() *Logger {
+	return &Logger{}
+}
+
+// Log implements maleo.Logger.
+func (l *Logger) Log(_ context.Context, entry maleo.Entry) {
+	l.record(newEntry(entry))
+}
+
+// LogError implements maleo.Logger.
+func (l *Logger) LogError(_ context.Context, err maleo.Error) {
+	e := newEntry(err)
+	e.Err = err
+	l.record(e)
+}
+
+func (l *Logger) record(e Entry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, e)
+}
+
+// Entries returns a query over the log entries.
+func (l *Logger) Entries() Entries {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	entries := make([]Entry, len(l.entries))
+	copy(entries, l.entries)
+	return newEntries("log entry", "log entries", entries)
+}
+
+// Reset removes the log entries.
+func (l *Logger) Reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = nil
+}
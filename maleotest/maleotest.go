@@ -0,0 +1,25 @@
+/*
+Package maleotest provides a recording Messenger and an in-memory Logger to assert on what the code under test
+logs and notifies.
+
+	func TestHandler(t *testing.T) {
+		m, messenger, logger := maleotest.New(maleo.Service{Name: "test"})
+		handler := NewHandler(m)
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+		maleotest.AssertNotified(t, messenger.Entries().Level(maleo.ErrorLevel).Code(500))
+		maleotest.AssertLogged(t, logger.Entries().MessageContains("failed to query"))
+	}
+*/
+package maleotest
+
+import "github.com/tigorlazuardi/maleo"
+
+// New returns a Maleo instance with a Logger and a registered Messenger. opts are applied after the Logger is set.
+func New(service maleo.Service, opts ...maleo.InitOption) (*maleo.Maleo, *Messenger, *Logger) {
+	logger := NewLogger()
+	messenger := NewMessenger("")
+	m := maleo.New(service, append([]maleo.InitOption{maleo.Option.Init().Logger(logger)}, opts...)...)
+	m.Register(messenger)
+	return m, messenger, logger
+}
@@ -0,0 +1,80 @@
+package maleotest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/tigorlazuardi/maleo"
+)
+
+type recordingTB struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestMaleoTest(t *testing.T) {
+	ctx := context.Background()
+	m, messenger, logger := New(maleo.Service{Name: "test"})
+
+	_ = m.Wrap(errors.New("connection refused")).Message("failed to query").Code(503).Key("db.query").Log(ctx).Notify(ctx)
+	m.NewEntry("user created").Key("user.create").Log(ctx)
+	m.Named("worker").NewEntry("job done").Level(maleo.WarnLevel).Notify(ctx)
+	if err := messenger.Wait(ctx); err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+
+	AssertNotified(t, messenger.Entries().Level(maleo.ErrorLevel).MessageContains("query").Code(503))
+	AssertNotified(t, messenger.Entries().Component("worker").Level(maleo.WarnLevel))
+	AssertNotNotified(t, messenger.Entries().Key("user.create"))
+	AssertLogged(t, logger.Entries().Key("user.create").Message("user created"))
+	AssertLogged(t, logger.Entries().ErrorContains("connection refused"))
+
+	entry, ok := messenger.Entries().HasError().First()
+	if !ok || entry.MessageContext == nil || entry.Key != "db.query" {
+		t.Errorf("First() = %+v, %v", entry, ok)
+	}
+	if got := messenger.Entries().MinLevel(maleo.WarnLevel).Messages(); len(got) != 2 {
+		t.Errorf("MinLevel(warn).Messages() = %v, want 2 messages", got)
+	}
+
+	messenger.Reset()
+	logger.Reset()
+	if !messenger.Entries().Empty() || logger.Entries().Len() != 0 {
+		t.Error("Reset() must remove the recorded entries")
+	}
+}
+
+func TestAssertNotified_Failure(t *testing.T) {
+	m, messenger, _ := New(maleo.Service{Name: "test"})
+	_ = m.Bail("boom").Code(500).Notify(context.Background())
+
+	tb := &recordingTB{TB: t}
+	AssertNotified(tb, messenger.Entries().Level(maleo.ErrorLevel).Code(404))
+	if len(tb.errors) != 1 {
+		t.Fatalf("expected 1 failure, got %d", len(tb.errors))
+	}
+	for _, want := range []string{
+		"expected notification matching Level(error).Code(404), found none",
+		"recorded notifications: 1",
+		`code=500 key="" message="boom"`,
+	} {
+		if !strings.Contains(tb.errors[0], want) {
+			t.Errorf("failure message %q does not contain %q", tb.errors[0], want)
+		}
+	}
+
+	tb = &recordingTB{TB: t}
+	AssertNotNotified(tb, messenger.Entries())
+	if len(tb.errors) != 1 || !strings.HasPrefix(tb.errors[0], "expected no notifications, found 1") {
+		t.Errorf("AssertNotNotified() failures = %v", tb.errors)
+	}
+}
@@ -0,0 +1,69 @@
+package maleotest
+
+import (
+	"context"
+	"sync"
+
+	"github.com/tigorlazuardi/maleo"
+)
+
+var _ maleo.Messenger = (*Messenger)(nil)
+
+// Messenger is a maleo.Messenger that records every message it receives.
+type Messenger struct {
+	name     string
+	mu       sync.Mutex
+	messages []maleo.MessageContext
+}
+
+// NewMessenger returns a Messenger with the given name. If name is empty, the name is "maleotest".
+func NewMessenger(name string) *Messenger {
+	if name == "" {
+		name = "maleotest"
+	}
+	return &Messenger{name: name}
+}
+
+// Name implements maleo.Messenger.
+func (m *Messenger) Name() string {
+	return m.name
+}
+
+// SendMessage implements maleo.Messenger. The message is recorded synchronously.
+func (m *Messenger) SendMessage(_ context.Context, msg maleo.MessageContext) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.messages = append(m.messages, msg)
+}
+
+// Wait implements maleo.Messenger. Messages are recorded synchronously, so Wait only returns the context error,
+// if any.
+func (m *Messenger) Wait(ctx context.Context) error {
+	return ctx.Err()
+}
+
+// Messages returns the recorded messages.
+func (m *Messenger) Messages() []maleo.MessageContext {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make([]maleo.MessageContext, len(m.messages))
+	copy(out, m.messages)
+	return out
+}
+
+// Entries returns a query over the recorded messages.
+func (m *Messenger) Entries() Entries {
+	messages := m.Messages()
+	entries := make([]Entry, 0, len(messages))
+	for _, msg := range messages {
+		entries = append(entries, entryFromMessage(msg))
+	}
+	return newEntries("notification", "notifications", entries)
+}
+
+// Reset removes the recorded messages.
+func (m *Messenger) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.messages = nil
+}
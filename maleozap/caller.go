@@ -15,3 +15,13 @@ func (ca Caller) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	enc.AddString("name", ca.Caller.ShortName())
 	return nil
 }
+
+// Stack renders the frames of maleo.StackHint as array of Caller.
+type Stack []maleo.Caller
+
+func (st Stack) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	for _, c := range st {
+		_ = enc.AppendObject(Caller{c})
+	}
+	return nil
+}
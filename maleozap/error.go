@@ -39,6 +39,11 @@ func (err Error) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	enc.AddInt("code", err.Code())
 	enc.AddString("message", err.Message())
 	_ = enc.AddObject("caller", Caller{err.Caller()})
+	if sh, ok := err.Error.(maleo.StackHint); ok {
+		if frames := sh.Frames(); len(frames) > 0 {
+			_ = enc.AddArray("stack", Stack(frames))
+		}
+	}
 	if key := err.Key(); key != "" {
 		enc.AddString("key", key)
 	}
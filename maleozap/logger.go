@@ -2,6 +2,7 @@ package maleozap
 
 import (
 	"context"
+	"time"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -21,7 +22,22 @@ func (f TraceCapturerFunc) CaptureTrace(ctx context.Context) []zap.Field {
 	return f(ctx)
 }
 
-type DisabledField uint8
+// TraceCapturerFromMaleo adapts a maleo.TraceCapturer, e.g. maleootel.TraceCapturer, to a TraceCapturer.
+func TraceCapturerFromMaleo(capturer maleo.TraceCapturer) TraceCapturer {
+	return TraceCapturerFunc(func(ctx context.Context) []zap.Field {
+		kvs := capturer.CaptureTrace(ctx)
+		if len(kvs) == 0 {
+			return nil
+		}
+		out := make([]zap.Field, 0, len(kvs))
+		for _, kv := range kvs {
+			out = append(out, zap.String(kv.Key, kv.Value))
+		}
+		return out
+	})
+}
+
+type DisabledField uint16
 
 func (d DisabledField) Has(flag DisabledField) bool {
 	return d&flag != 0
@@ -40,6 +56,7 @@ const (
 	DisableCode
 	DisableContext
 	DisableError
+	DisableStack
 
 	DisableNothing DisabledField = 0
 	DisableAll     DisabledField = ^DisableNothing
@@ -84,6 +101,11 @@ func (l *Logger) Log(ctx context.Context, entry maleo.Entry) {
 	if !l.flag.Has(DisableService) {
 		elements = append(elements, zap.Object("service", service(entry.Service())))
 	}
+	if ch, ok := entry.(maleo.ComponentHint); ok {
+		if component := ch.Component(); component != "" {
+			elements = append(elements, zap.String("component", component))
+		}
+	}
 	if !l.flag.Has(DisableKey) {
 		if key := entry.Key(); key != "" {
 			elements = append(elements, zap.String("key", key))
@@ -99,6 +121,13 @@ func (l *Logger) Log(ctx context.Context, entry maleo.Entry) {
 		elements = append(elements, zap.Object("caller", Caller{entry.Caller()}))
 	}
 
+	if !l.flag.Has(DisableContext) {
+		if cf, ok := entry.(maleo.ContextFieldsHint); ok {
+			if f := cf.ContextFields(); len(f) > 0 {
+				elements = append(elements, zap.Object("context_fields", fields(f)))
+			}
+		}
+	}
 	if !l.flag.Has(DisableContext) {
 		data := entry.Context()
 		if len(data) == 1 {
@@ -115,7 +144,7 @@ func (l *Logger) Log(ctx context.Context, entry maleo.Entry) {
 		}
 	}
 
-	l.Logger.Log(translateLevel(entry.Level()), entry.Message(), elements...)
+	l.write(translateLevel(entry.Level()), entry.Message(), elements)
 }
 
 func (l *Logger) LogError(ctx context.Context, err maleo.Error) {
@@ -129,17 +158,36 @@ func (l *Logger) LogError(ctx context.Context, err maleo.Error) {
 	if !l.flag.Has(DisableService) {
 		elements = append(elements, zap.Object("service", service(err.Service())))
 	}
+	if ch, ok := err.(maleo.ComponentHint); ok {
+		if component := ch.Component(); component != "" {
+			elements = append(elements, zap.String("component", component))
+		}
+	}
 	if !l.flag.Has(DisableCode) {
 		elements = append(elements, zap.Int("code", err.Code()))
 	}
 	if !l.flag.Has(DisableCaller) {
 		elements = append(elements, zap.Object("caller", Caller{err.Caller()}))
 	}
+	if !l.flag.Has(DisableStack) {
+		if sh, ok := err.(maleo.StackHint); ok {
+			if frames := sh.Frames(); len(frames) > 0 {
+				elements = append(elements, zap.Array("stack", Stack(frames)))
+			}
+		}
+	}
 	if !l.flag.Has(DisableKey) {
 		if key := err.Key(); key != "" {
 			elements = append(elements, zap.String("key", key))
 		}
 	}
+	if !l.flag.Has(DisableContext) {
+		if cf, ok := err.(maleo.ContextFieldsHint); ok {
+			if f := cf.ContextFields(); len(f) > 0 {
+				elements = append(elements, zap.Object("context_fields", fields(f)))
+			}
+		}
+	}
 	if !l.flag.Has(DisableContext) {
 		data := err.Context()
 		if len(data) == 1 {
@@ -160,5 +208,19 @@ func (l *Logger) LogError(ctx context.Context, err maleo.Error) {
 		elements = append(elements, toField("error", origin))
 	}
 
-	l.Logger.Log(translateLevel(err.Level()), err.Message(), elements...)
+	l.write(translateLevel(err.Level()), err.Message(), elements)
+}
+
+// write logs the record with the zap Logger.
+//
+// Records at the panic and fatal levels are written through the Core, because zap would panic or exit right after
+// writing them. maleo.Maleo terminates the program itself, after the Messengers are notified and waited.
+func (l *Logger) write(lvl zapcore.Level, msg string, fields []zap.Field) {
+	if lvl < zapcore.PanicLevel {
+		l.Logger.Log(lvl, msg, fields...)
+		return
+	}
+	if ce := l.Logger.Core().Check(zapcore.Entry{Level: lvl, Time: time.Now(), Message: msg}, nil); ce != nil {
+		ce.Write(fields...)
+	}
 }
@@ -0,0 +1,76 @@
+package maleozap_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/tigorlazuardi/maleo"
+	"github.com/tigorlazuardi/maleo/maleozap"
+)
+
+type recordingMessenger struct {
+	mu       sync.Mutex
+	messages []maleo.MessageContext
+}
+
+func (r *recordingMessenger) Name() string { return "recording" }
+
+func (r *recordingMessenger) SendMessage(_ context.Context, msg maleo.MessageContext) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.messages = append(r.messages, msg)
+}
+
+func (r *recordingMessenger) Wait(context.Context) error { return nil }
+
+func (r *recordingMessenger) len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.messages)
+}
+
+func TestLogger_Terminate(t *testing.T) {
+	ctx := context.Background()
+	core, logs := observer.New(zapcore.DebugLevel)
+	messenger := &recordingMessenger{}
+	exitCode := -1
+	m := maleo.New(maleo.Service{Name: "test"}, maleo.Option.Init().
+		Logger(maleozap.New(zap.New(core))).
+		Messengers(messenger).
+		ExitFunc(func(code int) { exitCode = code }).
+		TerminateTimeout(time.Second),
+	)
+
+	m.Fatal(errors.New("connection refused"), "failed to connect to database").Log(ctx)
+	if exitCode != 1 {
+		t.Errorf("expected maleo to exit with code 1, got %d", exitCode)
+	}
+	if got := messenger.len(); got != 1 {
+		t.Fatalf("expected the fatal error to reach the Messenger, got %d messages", got)
+	}
+	if got := logs.FilterLevelExact(zapcore.FatalLevel).Len(); got != 1 {
+		t.Errorf("expected 1 log at the fatal level, got %d", got)
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("expected maleo to panic")
+			}
+		}()
+		m.Panic(errors.New("invariant broken"), "corrupted state").Log(ctx)
+	}()
+	if got := messenger.len(); got != 2 {
+		t.Errorf("expected the panic error to reach the Messenger, got %d messages", got)
+	}
+	if got := logs.FilterLevelExact(zapcore.PanicLevel).Len(); got != 1 {
+		t.Errorf("expected 1 log at the panic level, got %d", got)
+	}
+}
@@ -20,5 +20,14 @@ func (s service) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	if s.Version != "" {
 		enc.AddString("version", s.Version)
 	}
+	if s.Instance != "" {
+		enc.AddString("instance", s.Instance)
+	}
+	if s.Region != "" {
+		enc.AddString("region", s.Region)
+	}
+	if s.Commit != "" {
+		enc.AddString("commit", s.Commit)
+	}
 	return nil
 }
@@ -42,3 +42,19 @@ func (m entryMessageContext) Cooldown() time.Duration {
 func (m entryMessageContext) Maleo() *Maleo {
 	return m.param.Maleo
 }
+
+// Component returns the component name of the entry, or of the Maleo instance that sends this message.
+func (m entryMessageContext) Component() string {
+	if ch, ok := m.Entry.(ComponentHint); ok {
+		return ch.Component()
+	}
+	return m.param.Maleo.Component()
+}
+
+// ContextFields returns the inherited and request scoped fields of the entry.
+func (m entryMessageContext) ContextFields() Fields {
+	if cf, ok := m.Entry.(ContextFieldsHint); ok {
+		return cf.ContextFields()
+	}
+	return m.param.Maleo.Fields()
+}
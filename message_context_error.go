@@ -44,3 +44,19 @@ func (e errorMessageContext) Maleo() *Maleo {
 func (e errorMessageContext) Cooldown() time.Duration {
 	return e.param.Cooldown
 }
+
+// Component returns the component name of the error, or of the Maleo instance that sends this message.
+func (e errorMessageContext) Component() string {
+	if ch, ok := e.Error.(ComponentHint); ok { //nolint:errorlint
+		return ch.Component()
+	}
+	return e.param.Maleo.Component()
+}
+
+// ContextFields returns the inherited and request scoped fields of the error.
+func (e errorMessageContext) ContextFields() Fields {
+	if cf, ok := e.Error.(ContextFieldsHint); ok { //nolint:errorlint
+		return cf.ContextFields()
+	}
+	return e.param.Maleo.Fields()
+}
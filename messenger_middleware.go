@@ -0,0 +1,355 @@
+package maleo
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MessengerMiddleware wraps a Messenger with extra behaviour.
+//
+// The Messenger returned by the built-in middlewares keeps the Name and Wait of the wrapped Messenger,
+// so the wrapped Messenger can be registered with Maleo.Register or Maleo.RegisterBenched in place of the original,
+// and still be picked by name by the message options, MessengerLevel and routing rules.
+type MessengerMiddleware func(Messenger) Messenger
+
+/*
+Chain wraps the Messenger with the middlewares. The first middleware is the outermost, so it sees the messages first.
+
+Example:
+
+	m.Register(maleo.Chain(discord,
+		maleo.Middleware.Recover(),
+		maleo.Middleware.Level(maleo.WarnLevel),
+		maleo.Middleware.Timeout(time.Minute),
+	))
+*/
+func Chain(messenger Messenger, middlewares ...MessengerMiddleware) Messenger {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		messenger = middlewares[i](messenger)
+	}
+	return messenger
+}
+
+// UnwrapMessenger returns the Messenger wrapped by a built-in middleware. Returns nil if messenger is not wrapped.
+func UnwrapMessenger(messenger Messenger) Messenger {
+	if u, ok := messenger.(interface{ Unwrap() Messenger }); ok {
+		return u.Unwrap()
+	}
+	return nil
+}
+
+// messengerFunc passes Name and Wait through to the wrapped Messenger, and sends the messages with send.
+type messengerFunc struct {
+	Messenger
+	send func(ctx context.Context, msg MessageContext)
+}
+
+func (m *messengerFunc) SendMessage(ctx context.Context, msg MessageContext) {
+	m.send(ctx, msg)
+}
+
+func (m *messengerFunc) Unwrap() Messenger {
+	return m.Messenger
+}
+
+func wrapMessenger(next Messenger, send func(ctx context.Context, msg MessageContext)) Messenger {
+	return &messengerFunc{Messenger: next, send: send}
+}
+
+type middleware struct{}
+
+// Middleware holds the built-in MessengerMiddlewares.
+var Middleware middleware
+
+// Recover recovers panics from SendMessage and Wait of the wrapped Messenger.
+//
+// Panics from SendMessage are turned into Errors and logged the same way Recover does, using the Maleo instance of
+// the message. Panics from Wait are returned as *PanicError.
+func (middleware) Recover(opts ...RecoverOption) MessengerMiddleware {
+	return func(next Messenger) Messenger {
+		opts := append([]RecoverOption{Option.Recover().Message("messenger %s panicked", next.Name())}, opts...)
+		return &recoverMessenger{Messenger: next, opts: opts}
+	}
+}
+
+type recoverMessenger struct {
+	Messenger
+	opts []RecoverOption
+}
+
+func (r *recoverMessenger) SendMessage(ctx context.Context, msg MessageContext) {
+	defer Recover(ContextWithMaleo(ctx, msg.Maleo()), r.opts...)
+	r.Messenger.SendMessage(ctx, msg)
+}
+
+func (r *recoverMessenger) Wait(ctx context.Context) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = NewPanicError(v)
+		}
+	}()
+	return r.Messenger.Wait(ctx)
+}
+
+func (r *recoverMessenger) Unwrap() Messenger {
+	return r.Messenger
+}
+
+// Timeout gives the context of the messages a deadline of d, so the wrapped Messenger gives up on sending the message
+// after d. The deadline applies even if the wrapped Messenger sends the message asynchronously.
+func (middleware) Timeout(d time.Duration) MessengerMiddleware {
+	return func(next Messenger) Messenger {
+		return wrapMessenger(next, func(ctx context.Context, msg MessageContext) {
+			ctx, cancel := context.WithTimeout(ctx, d)
+			// The wrapped Messenger may still use the context after SendMessage returns,
+			// so the context is only released after the deadline.
+			time.AfterFunc(d, cancel)
+			next.SendMessage(ctx, msg)
+		})
+	}
+}
+
+// Level only passes the messages with level enabled by lvl to the wrapped Messenger.
+func (middleware) Level(lvl LevelEnabler) MessengerMiddleware {
+	return Middleware.Filter(func(_ context.Context, msg MessageContext) bool {
+		return lvl.Enabled(msg.Level())
+	})
+}
+
+// Filter only passes the messages that the filter returns true for to the wrapped Messenger.
+func (middleware) Filter(filter func(ctx context.Context, msg MessageContext) bool) MessengerMiddleware {
+	return func(next Messenger) Messenger {
+		return wrapMessenger(next, func(ctx context.Context, msg MessageContext) {
+			if filter(ctx, msg) {
+				next.SendMessage(ctx, msg)
+			}
+		})
+	}
+}
+
+// Transform replaces the messages with the result of transform before they are passed to the wrapped Messenger,
+// e.g. to redact sensitive data. Return nil from transform to drop the message.
+func (middleware) Transform(transform func(ctx context.Context, msg MessageContext) MessageContext) MessengerMiddleware {
+	return func(next Messenger) Messenger {
+		return wrapMessenger(next, func(ctx context.Context, msg MessageContext) {
+			if msg = transform(ctx, msg); msg != nil {
+				next.SendMessage(ctx, msg)
+			}
+		})
+	}
+}
+
+/*
+Redact masks the sensitive data in the Context and ContextFields of the messages with redactor, e.g. one created by
+NewRedactor, before they are passed to the wrapped Messenger. Use it to redact more for some Messengers than for the
+logs, or to redact the fields added to the messages by Hooks.
+
+The Redactor of the Engine of the Maleo instance that sends the message is used if redactor is nil.
+
+Example:
+
+	m.Register(maleo.Chain(discord,
+		maleo.Middleware.Redact(maleo.NewRedactor(maleo.Option.Redact().Defaults().Keys("email"))),
+	))
+*/
+func (middleware) Redact(redactor Redactor) MessengerMiddleware {
+	return func(next Messenger) Messenger {
+		return wrapMessenger(next, func(ctx context.Context, msg MessageContext) {
+			switch {
+			case redactor != nil:
+				msg = &redactedMessageContext{MessageContext: msg, redact: redactor.Redact}
+			case msg.Maleo() != nil:
+				msg = &redactedMessageContext{MessageContext: msg, redact: msg.Maleo().Redact}
+			}
+			next.SendMessage(ctx, msg)
+		})
+	}
+}
+
+// Observe calls observe after every SendMessage of the wrapped Messenger, with the time SendMessage took,
+// e.g. to collect metrics. observe is not called if SendMessage panics.
+func (middleware) Observe(observe func(ctx context.Context, msg MessageContext, name string, elapsed time.Duration)) MessengerMiddleware {
+	return func(next Messenger) Messenger {
+		return wrapMessenger(next, func(ctx context.Context, msg MessageContext) {
+			start := time.Now()
+			next.SendMessage(ctx, msg)
+			observe(ctx, msg, next.Name(), time.Since(start))
+		})
+	}
+}
+
+// DeliveryMessenger is implemented by Messengers that can deliver a message synchronously and report whether it
+// failed. Middleware.Retry uses Deliver instead of SendMessage to know when to retry.
+type DeliveryMessenger interface {
+	Messenger
+	// Deliver sends the message and returns after it is delivered or failed.
+	Deliver(ctx context.Context, msg MessageContext) error
+}
+
+// Backoff returns how long to wait before the given retry. attempt starts from 1 for the first retry.
+type Backoff func(attempt int) time.Duration
+
+// ConstantBackoff waits d before every retry.
+func ConstantBackoff(d time.Duration) Backoff {
+	return func(int) time.Duration {
+		return d
+	}
+}
+
+// ExponentialBackoff waits base before the first retry, and doubles the wait for every next retry, up to max.
+func ExponentialBackoff(base, max time.Duration) Backoff {
+	return func(attempt int) time.Duration {
+		d := base
+		for i := 1; i < attempt && d < max; i++ {
+			d *= 2
+		}
+		if d > max {
+			return max
+		}
+		return d
+	}
+}
+
+/*
+Retry sends the messages up to attempts times to the wrapped Messenger, waiting by backoff between the attempts.
+
+A message is retried if SendMessage panics, or if Deliver returns an error when the wrapped Messenger implements
+DeliveryMessenger. Messengers that implement DeliveryMessenger, e.g. maleodiscord.Discord, are delivered in the
+background, so the caller is not blocked by slow posts. Otherwise the first attempt runs in SendMessage and only the
+retries run in the background.
+
+The retries stop when the context of the message ends, or when the context given to Wait ends. Wait waits for the
+pending retries before waiting for the wrapped Messenger.
+
+The message is logged with the Maleo instance of the message when all attempts fail, or when the retries are stopped.
+
+Example:
+
+	m.Register(maleo.Chain(discord,
+		maleo.Middleware.Retry(3, maleo.ExponentialBackoff(time.Second, 30*time.Second)),
+	))
+*/
+func (middleware) Retry(attempts int, backoff Backoff) MessengerMiddleware {
+	if attempts < 1 {
+		attempts = 1
+	}
+	if backoff == nil {
+		backoff = ConstantBackoff(0)
+	}
+	return func(next Messenger) Messenger {
+		return &retryMessenger{Messenger: next, attempts: attempts, backoff: backoff}
+	}
+}
+
+type retryMessenger struct {
+	Messenger
+	attempts int
+	backoff  Backoff
+	pending  sync.WaitGroup
+	mu       sync.Mutex
+	stop     chan struct{}
+}
+
+func (r *retryMessenger) SendMessage(ctx context.Context, msg MessageContext) {
+	if _, ok := r.Messenger.(DeliveryMessenger); ok {
+		r.pending.Add(1)
+		go func() {
+			defer r.pending.Done()
+			r.retry(ctx, msg, 0, nil)
+		}()
+		return
+	}
+	err := r.send(ctx, msg)
+	if err == nil {
+		return
+	}
+	if r.attempts == 1 {
+		r.fail(ctx, msg, 1, err)
+		return
+	}
+	r.pending.Add(1)
+	go func() {
+		defer r.pending.Done()
+		r.retry(ctx, msg, 1, err)
+	}()
+}
+
+// retry makes the attempts left after the given number of attempts, which failed with err.
+func (r *retryMessenger) retry(ctx context.Context, msg MessageContext, attempt int, err error) {
+	stop := r.stopped()
+	for ; attempt < r.attempts; attempt++ {
+		if attempt > 0 {
+			timer := time.NewTimer(r.backoff(attempt))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				r.fail(ctx, msg, attempt, err)
+				return
+			case <-stop:
+				timer.Stop()
+				r.fail(ctx, msg, attempt, err)
+				return
+			case <-timer.C:
+			}
+		}
+		if err = r.send(ctx, msg); err == nil {
+			return
+		}
+	}
+	r.fail(ctx, msg, attempt, err)
+}
+
+// stopped returns the channel that is closed when the context given to Wait ends.
+func (r *retryMessenger) stopped() <-chan struct{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.stop == nil {
+		r.stop = make(chan struct{})
+	}
+	return r.stop
+}
+
+// send makes one attempt to send the message. Panics are returned as *PanicError.
+func (r *retryMessenger) send(ctx context.Context, msg MessageContext) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = NewPanicError(v)
+		}
+	}()
+	if dm, ok := r.Messenger.(DeliveryMessenger); ok {
+		return dm.Deliver(ctx, msg)
+	}
+	r.Messenger.SendMessage(ctx, msg)
+	return nil
+}
+
+func (r *retryMessenger) fail(ctx context.Context, msg MessageContext, attempts int, err error) {
+	_ = msg.Maleo().Wrap(err).Caller(msg.Caller()).
+		Message("%s: failed to send message after %d attempts", r.Name(), attempts).Log(ctx)
+}
+
+// Wait waits for the pending retries, then for the wrapped Messenger. The pending retries are stopped when ctx ends.
+func (r *retryMessenger) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		r.pending.Wait()
+		close(done)
+	}()
+	select {
+	case <-ctx.Done():
+		r.mu.Lock()
+		if r.stop != nil {
+			close(r.stop)
+			r.stop = nil
+		}
+		r.mu.Unlock()
+		return ctx.Err()
+	case <-done:
+	}
+	return r.Messenger.Wait(ctx)
+}
+
+func (r *retryMessenger) Unwrap() Messenger {
+	return r.Messenger
+}
@@ -0,0 +1,225 @@
+package maleo
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type panickingMessenger struct {
+	name string
+}
+
+func (p panickingMessenger) Name() string                                { return p.name }
+func (p panickingMessenger) SendMessage(context.Context, MessageContext) { panic("send failed") }
+func (p panickingMessenger) Wait(context.Context) error                  { panic("wait failed") }
+
+type flakyMessenger struct {
+	failures int
+	attempts int32
+}
+
+func (f *flakyMessenger) Name() string                                { return "flaky" }
+func (f *flakyMessenger) SendMessage(context.Context, MessageContext) {}
+func (f *flakyMessenger) Wait(context.Context) error                  { return nil }
+func (f *flakyMessenger) Deliver(context.Context, MessageContext) error {
+	if int(atomic.AddInt32(&f.attempts, 1)) <= f.failures {
+		return errors.New("delivery failed")
+	}
+	return nil
+}
+
+func TestChain(t *testing.T) {
+	var order []string
+	trace := func(name string) MessengerMiddleware {
+		return Middleware.Filter(func(context.Context, MessageContext) bool {
+			order = append(order, name)
+			return true
+		})
+	}
+	inner := &countingMessenger{name: "inner"}
+	chained := Chain(inner, trace("first"), trace("second"))
+	if chained.Name() != "inner" {
+		t.Errorf("Name() = %v, want %v", chained.Name(), "inner")
+	}
+
+	m := New(Service{Name: "test"}, Option.Init().MessengerLevel("inner", ErrorLevel))
+	m.Register(chained)
+	m.NewEntry("info").Notify(context.Background())
+	_ = m.Bail("boom").Notify(context.Background())
+	if inner.count != 1 {
+		t.Errorf("expected 1 message by MessengerLevel of the wrapped name, got %d", inner.count)
+	}
+	if strings.Join(order, ",") != "first,second" {
+		t.Errorf("middleware order = %v, want first,second", order)
+	}
+	if UnwrapMessenger(UnwrapMessenger(chained)) != inner {
+		t.Error("UnwrapMessenger() does not return the wrapped Messenger")
+	}
+	if UnwrapMessenger(inner) != nil {
+		t.Error("UnwrapMessenger() of unwrapped Messenger must be nil")
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Recover", func(t *testing.T) {
+		m, logger := NewTestingMaleo()
+		var recovered Error
+		messenger := Chain(panickingMessenger{name: "panicky"}, Middleware.Recover(Option.Recover().Callback(func(_ context.Context, err Error) {
+			recovered = err
+		})))
+		m.Register(messenger)
+		m.NewEntry("hello").Notify(ctx)
+		if recovered == nil {
+			t.Fatal("expected panic to be recovered")
+		}
+		if recovered.Message() != "messenger panicky panicked" || recovered.Level() != PanicLevel {
+			t.Errorf("recovered error = %v with level %v", recovered.Message(), recovered.Level())
+		}
+		if !strings.Contains(logger.String(), "send failed") {
+			t.Errorf("expected panic to be logged, got %s", logger.String())
+		}
+		var pe *PanicError
+		if err := messenger.Wait(ctx); !errors.As(err, &pe) || pe.Value != "wait failed" {
+			t.Errorf("Wait() error = %v, want PanicError", err)
+		}
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		m, _ := NewTestingMaleo()
+		inner := &recordingMessenger{}
+		m.Register(Chain(inner, Middleware.Timeout(time.Minute)))
+		m.NewEntry("hello").Notify(ctx)
+		deadline, ok := inner.ctx.Deadline()
+		if !ok || time.Until(deadline) > time.Minute {
+			t.Errorf("expected context deadline within a minute, got %v, %v", deadline, ok)
+		}
+		if inner.ctx.Err() != nil {
+			t.Errorf("context must stay alive after SendMessage returns, got %v", inner.ctx.Err())
+		}
+	})
+
+	t.Run("Level", func(t *testing.T) {
+		m, _ := NewTestingMaleo()
+		inner := &countingMessenger{name: "inner"}
+		m.Register(Chain(inner, Middleware.Level(WarnLevel)))
+		m.NewEntry("info").Notify(ctx)
+		m.NewEntry("warn").Level(WarnLevel).Notify(ctx)
+		if inner.count != 1 {
+			t.Errorf("expected 1 message, got %d", inner.count)
+		}
+	})
+
+	t.Run("Transform", func(t *testing.T) {
+		m, _ := NewTestingMaleo()
+		inner := &recordingMessenger{}
+		m.Register(Chain(inner, Middleware.Transform(func(_ context.Context, msg MessageContext) MessageContext {
+			if msg.Key() == "drop" {
+				return nil
+			}
+			return msg
+		})))
+		m.NewEntry("dropped").Key("drop").Notify(ctx)
+		m.NewEntry("kept").Notify(ctx)
+		if len(inner.messages) != 1 || inner.messages[0].Message() != "kept" {
+			t.Errorf("expected only the kept message, got %d messages", len(inner.messages))
+		}
+	})
+
+	t.Run("Redact", func(t *testing.T) {
+		m, _ := NewTestingMaleo()
+		inner := &recordingMessenger{}
+		m.Register(Chain(inner, Middleware.Redact(NewRedactor(Option.Redact().Keys("password", "token")))))
+		m.NewEntry("login").Context("user", "foo", "password", "hunter2").Fields(F{"token": "abc"}).Notify(ctx)
+		msg := inner.messages[0]
+		if data := msg.Context(); data[1] != "foo" || data[3] == "hunter2" {
+			t.Errorf("Context() = %v, want the password redacted", data)
+		}
+		if fields := msg.(ContextFieldsHint).ContextFields(); fields["token"] == "abc" {
+			t.Errorf("ContextFields() = %v, want the token redacted", fields)
+		}
+	})
+
+	t.Run("Observe", func(t *testing.T) {
+		m, _ := NewTestingMaleo()
+		var observed []string
+		m.Register(Chain(&countingMessenger{name: "inner"}, Middleware.Observe(func(_ context.Context, msg MessageContext, name string, _ time.Duration) {
+			observed = append(observed, name+":"+msg.Message())
+		})))
+		m.NewEntry("hello").Notify(ctx)
+		if strings.Join(observed, ",") != "inner:hello" {
+			t.Errorf("observed = %v, want inner:hello", observed)
+		}
+	})
+
+	t.Run("Retry", func(t *testing.T) {
+		m, logger := NewTestingMaleo()
+		inner := &flakyMessenger{failures: 2}
+		m.Register(Chain(inner, Middleware.Retry(3, ConstantBackoff(time.Millisecond))))
+		m.NewEntry("hello").Notify(ctx)
+		if err := m.Wait(ctx); err != nil {
+			t.Fatalf("Wait() error = %v", err)
+		}
+		if got := atomic.LoadInt32(&inner.attempts); got != 3 {
+			t.Errorf("expected 3 attempts, got %d", got)
+		}
+		if logger.String() != "" {
+			t.Errorf("expected nothing logged after a successful retry, got %s", logger.String())
+		}
+	})
+
+	t.Run("Retry exhausted", func(t *testing.T) {
+		m, logger := NewTestingMaleo()
+		m.Register(Chain(panickingMessenger{name: "panicky"}, Middleware.Recover(), Middleware.Retry(2, nil)))
+		m.NewEntry("hello").Notify(ctx)
+		if err := m.Wait(ctx); err == nil || !strings.Contains(err.Error(), "wait failed") {
+			t.Errorf("Wait() error = %v, want the panic of the wrapped Wait after the retries", err)
+		}
+		out := logger.String()
+		if !strings.Contains(out, "panicky: failed to send message after 2 attempts") || !strings.Contains(out, "send failed") {
+			t.Errorf("expected the failure to be logged, got %s", out)
+		}
+	})
+
+	t.Run("Retry stopped by Wait", func(t *testing.T) {
+		m, logger := NewTestingMaleo()
+		inner := &flakyMessenger{failures: 10}
+		m.Register(Chain(inner, Middleware.Retry(5, ConstantBackoff(time.Hour))))
+		m.NewEntry("hello").Notify(ctx)
+		waitCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		if err := m.Wait(waitCtx); err == nil {
+			t.Fatalf("expected Wait to end with its context")
+		}
+		if err := m.Wait(ctx); err != nil {
+			t.Fatalf("expected the retries to be stopped, got %v", err)
+		}
+		if got := atomic.LoadInt32(&inner.attempts); got != 1 {
+			t.Errorf("expected 1 attempt, got %d", got)
+		}
+		if !strings.Contains(logger.String(), "flaky: failed to send message after 1 attempts") {
+			t.Errorf("expected the stopped retries to be logged, got %s", logger.String())
+		}
+	})
+
+	t.Run("Retry stopped by the message context", func(t *testing.T) {
+		inner := &flakyMessenger{failures: 10}
+		retry := Middleware.Retry(5, ConstantBackoff(time.Hour))(inner)
+		msgCtx, cancel := context.WithCancel(ctx)
+		retry.SendMessage(msgCtx, notifyAndCapture(func(m *Maleo) { m.NewEntry("hello").Notify(ctx) }))
+		for atomic.LoadInt32(&inner.attempts) == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		cancel()
+		waitCtx, cancelWait := context.WithTimeout(ctx, time.Second)
+		defer cancelWait()
+		if err := retry.Wait(waitCtx); err != nil {
+			t.Fatalf("expected the retries to stop with the message context, got %v", err)
+		}
+	})
+}
@@ -15,3 +15,27 @@ func (option) Engine() EngineOptionBuilder {
 func (option) Message() MessageOptionBuilder {
 	return MessageOptionBuilder{}
 }
+
+func (option) Definition() DefinitionOptionBuilder {
+	return DefinitionOptionBuilder{}
+}
+
+func (option) Digest() DigestOptionBuilder {
+	return DigestOptionBuilder{}
+}
+
+func (option) Recover() RecoverOptionBuilder {
+	return RecoverOptionBuilder{}
+}
+
+func (option) Redact() RedactOptionBuilder {
+	return RedactOptionBuilder{}
+}
+
+func (option) Truncate() TruncateOptionBuilder {
+	return TruncateOptionBuilder{}
+}
+
+func (option) DetectService() DetectServiceOptionBuilder {
+	return DetectServiceOptionBuilder{}
+}
@@ -1,31 +1,100 @@
 package maleo
 
-import "errors"
-
 // Query is a namespace group that holds the maleo's Query functions.
 //
 // Methods and functions under Query are utilities to search values in the error stack.
+//
+// The error stack is treated as a tree. Errors that implement `Unwrap() error` have a single child, while errors
+// that implement `Unwrap() []error` (e.g. errors created by errors.Join or fmt.Errorf with multiple %w verbs) have
+// multiple children. Query functions walk the tree in the same order as errors.Is and errors.As do:
+// depth-first pre-order, where the children are visited in the order they are returned by Unwrap.
 var Query query
 
 type query struct{}
 
+// WalkFunc is the visitor function for Query.Walk. Return false to stop walking the error tree.
+type WalkFunc = func(err error) bool
+
 /*
-GetHTTPCode Search for any error in the stack that implements HTTPCodeHint and return that value.
+Walk visits every error in the error tree, starting with err itself, depth-first pre-order.
 
-The API searches from the outermost error, and will return the first value it found.
+Errors implementing `Unwrap() error` and `Unwrap() []error` are both followed, so every branch of joined errors
+is visited. Nil errors are not visited.
 
-Return 500 if there's no error that implements HTTPCodeHint in the stack.
+Walking stops when fn returns false.
 */
-func (query) GetHTTPCode(err error) (code int) {
+func (query) Walk(err error, fn WalkFunc) {
+	walk(err, fn)
+}
+
+// walk returns false if the walk is stopped by fn.
+func walk(err error, fn WalkFunc) bool {
 	if err == nil {
-		return 500
+		return true
+	}
+	if !fn(err) {
+		return false
+	}
+	for _, child := range unwrapAll(err) {
+		if !walk(child, fn) {
+			return false
+		}
 	}
+	return true
+}
 
-	if ch, ok := err.(HTTPCodeHint); ok { //nolint:errorlint
-		return ch.HTTPCode()
+// unwrapAll returns the direct children of err in the error tree.
+func unwrapAll(err error) []error {
+	switch e := err.(type) { //nolint:errorlint
+	case interface{ Unwrap() []error }:
+		return e.Unwrap()
+	case interface{ Unwrap() error }:
+		if inner := e.Unwrap(); inner != nil {
+			return []error{inner}
+		}
 	}
+	return nil
+}
 
-	return Query.GetHTTPCode(errors.Unwrap(err))
+// find returns the first error in the error tree that matches the predicate.
+func find(err error, predicate func(err error) bool) (found error) {
+	walk(err, func(err error) bool {
+		if predicate(err) {
+			found = err
+			return false
+		}
+		return true
+	})
+	return found
+}
+
+/*
+GetHTTPCode Search for any error in the stack that implements HTTPCodeHint and return that value.
+
+The API searches from the outermost error, and will return the first value it found. Errors with HTTP codes set
+explicitly, e.g. by ErrorBuilder.HTTPCode, are preferred over maleo Errors whose HTTP codes are only derived from their
+codes, so an outer wrapper with only a business code does not hide the HTTP code of the inner error.
+
+Return 500 if there's no error that implements HTTPCodeHint in the stack.
+*/
+func (query) GetHTTPCode(err error) (code int) {
+	found := find(err, func(err error) bool {
+		if d, ok := err.(derivedHTTPCode); ok && d.isHTTPCodeDerived() { //nolint:errorlint
+			return false
+		}
+		_, ok := err.(HTTPCodeHint) //nolint:errorlint
+		return ok
+	})
+	if found == nil {
+		found = find(err, func(err error) bool {
+			_, ok := err.(HTTPCodeHint) //nolint:errorlint
+			return ok
+		})
+	}
+	if found == nil {
+		return 500
+	}
+	return found.(HTTPCodeHint).HTTPCode() //nolint:errorlint
 }
 
 /*
@@ -38,15 +107,14 @@ Return 500 if there's no error that implements CodeHint in the stack.
 Used by maleo to search Code.
 */
 func (query) GetCodeHint(err error) (code int) {
-	if err == nil {
+	found := find(err, func(err error) bool {
+		_, ok := err.(CodeHint) //nolint:errorlint
+		return ok
+	})
+	if found == nil {
 		return 500
 	}
-
-	if ch, ok := err.(CodeHint); ok { //nolint:errorlint
-		return ch.Code()
-	}
-
-	return Query.GetCodeHint(errors.Unwrap(err))
+	return found.(CodeHint).Code() //nolint:errorlint
 }
 
 /*
@@ -59,15 +127,35 @@ Return empty string if there's no error that implements MessageHint in the stack
 Used by maleo to search Message in the error.
 */
 func (query) GetMessage(err error) (message string) {
-	if err == nil {
+	found := find(err, func(err error) bool {
+		_, ok := err.(MessageHint) //nolint:errorlint
+		return ok
+	})
+	if found == nil {
 		return ""
 	}
+	return found.(MessageHint).Message() //nolint:errorlint
+}
 
-	if ch, ok := err.(MessageHint); ok { //nolint:errorlint
-		return ch.Message()
-	}
+/*
+GetPublicMessage Search for any error in the stack that implements PublicMessageHint with non-empty value and return
+that value.
+
+The API searches from the outermost error, and will return the first value it found.
 
-	return Query.GetMessage(errors.Unwrap(err))
+Return empty string if there's no error with public message in the stack.
+
+Used by maleohttp to build the error responses.
+*/
+func (query) GetPublicMessage(err error) (message string) {
+	found := find(err, func(err error) bool {
+		pm, ok := err.(PublicMessageHint) //nolint:errorlint
+		return ok && pm.PublicMessage() != ""
+	})
+	if found == nil {
+		return ""
+	}
+	return found.(PublicMessageHint).PublicMessage() //nolint:errorlint
 }
 
 /*
@@ -84,26 +172,14 @@ eventually returns nil when nothing in the stack implements those three and have
 The search operation is "Breath First", meaning the maleo.Error is tested for CodeHint and HTTPCodeHint first before moving on.
 */
 func (query) SearchCode(err error, code int) Error {
-	if err == nil {
+	found := find(err, func(err error) bool {
+		e, ok := err.(Error) //nolint:errorlint
+		return ok && (e.Code() == code || e.HTTPCode() == code)
+	})
+	if found == nil {
 		return nil
 	}
-
-	// It's important to not use the other Search API for brevity.
-	// This is because we are
-
-	if ch, ok := err.(CodeHint); ok && ch.Code() == code { //nolint:errorlint
-		if err, ok := err.(Error); ok { //nolint:errorlint
-			return err
-		}
-	}
-
-	if ch, ok := err.(HTTPCodeHint); ok && ch.HTTPCode() == code { //nolint:errorlint
-		if err, ok := err.(Error); ok { //nolint:errorlint
-			return err
-		}
-	}
-
-	return Query.SearchCode(errors.Unwrap(err), code)
+	return found.(Error) //nolint:errorlint
 }
 
 /*
@@ -114,17 +190,14 @@ Given Code will be tested and the maleo.Error is returned if any of the error in
 Otherwise, this function will look deeper into the stack and eventually returns nil when nothing in the stack implements CodeHint.
 */
 func (query) SearchCodeHint(err error, code int) Error {
-	if err == nil {
+	found := find(err, func(err error) bool {
+		e, ok := err.(Error) //nolint:errorlint
+		return ok && e.Code() == code
+	})
+	if found == nil {
 		return nil
 	}
-
-	if ch, ok := err.(CodeHint); ok && ch.Code() == code { //nolint:errorlint
-		if err, ok := err.(Error); ok { //nolint:errorlint
-			return err
-		}
-	}
-
-	return Query.SearchCodeHint(errors.Unwrap(err), code)
+	return found.(Error) //nolint:errorlint
 }
 
 /*
@@ -135,36 +208,28 @@ Given Code will be tested and the maleo.Error is returned if any of the error in
 Otherwise, this function will look deeper into the stack and eventually returns nil when nothing in the stack implements HTTPCodeHint.
 */
 func (query) SearchHTTPCode(err error, code int) Error {
-	if err == nil {
+	found := find(err, func(err error) bool {
+		e, ok := err.(Error) //nolint:errorlint
+		return ok && e.HTTPCode() == code
+	})
+	if found == nil {
 		return nil
 	}
-
-	if ch, ok := err.(HTTPCodeHint); ok && ch.HTTPCode() == code { //nolint:errorlint
-		if err, ok := err.(Error); ok { //nolint:errorlint
-			return err
-		}
-	}
-
-	return Query.SearchHTTPCode(errors.Unwrap(err), code)
+	return found.(Error) //nolint:errorlint
 }
 
 // CollectErrors Collects all the maleo.Error in the error stack.
 //
-// It is sorted from the top most error to the bottom most error.
+// It is sorted from the top most error to the bottom most error, following the walk order of Query.Walk.
 func (query) CollectErrors(err error) []Error {
-	return collectErrors(err, make([]Error, 0, 4))
-}
-
-func collectErrors(err error, input []Error) []Error {
-	if err == nil {
-		return input
-	}
-
-	if err, ok := err.(Error); ok { //nolint:errorlint
-		input = append(input, err)
-	}
-
-	return collectErrors(errors.Unwrap(err), input)
+	out := make([]Error, 0, 4)
+	walk(err, func(err error) bool {
+		if e, ok := err.(Error); ok { //nolint:errorlint
+			out = append(out, e)
+		}
+		return true
+	})
+	return out
 }
 
 type ErrorStack struct {
@@ -175,67 +240,73 @@ type ErrorStack struct {
 // GetStack Gets the error stack by checking CallerHint.
 //
 // maleo recursively checks the given error if it implements CallerHint until all the error in the stack are checked.
+// Every branch of joined errors is checked, following the walk order of Query.Walk.
 //
 // If you wish to get list of maleo.Error use CollectErrors instead.
 func (query) GetStack(err error) []ErrorStack {
-	in := make([]ErrorStack, 0, 8)
-	return getStackList(err, in)
-}
-
-func getStackList(err error, input []ErrorStack) []ErrorStack {
-	if err == nil {
-		return input
-	}
-	if ch, ok := err.(CallerHint); ok { //nolint:errorlint
-		return append(input, ErrorStack{Caller: ch.Caller(), Error: err})
-	}
-	return getStackList(errors.Unwrap(err), input)
+	out := make([]ErrorStack, 0, 8)
+	walk(err, func(err error) bool {
+		if ch, ok := err.(CallerHint); ok { //nolint:errorlint
+			out = append(out, ErrorStack{Caller: ch.Caller(), Error: err})
+		}
+		return true
+	})
+	return out
 }
 
 // TopError Gets the Top most maleo.Error instance in the error stack.
 // Returns nil if no maleo.Error instance found in the stack.
 func (query) TopError(err error) Error {
-	if err == nil {
+	found := find(err, func(err error) bool {
+		_, ok := err.(Error) //nolint:errorlint
+		return ok
+	})
+	if found == nil {
 		return nil
 	}
-
-	if e, ok := err.(Error); ok { //nolint:errorlint
-		return e
-	}
-
-	return Query.TopError(errors.Unwrap(err))
+	return found.(Error) //nolint:errorlint
 }
 
 // BottomError Gets the bottom most maleo.Error instance in the error stack.
 // Returns nil if no maleo.Error instance found in the stack.
+//
+// When the stack has multiple branches, the bottom most maleo.Error is the last maleo.Error visited
+// in the walk order of Query.Walk.
 func (query) BottomError(err error) Error {
-	top := Query.TopError(err)
-	if top == nil {
-		return nil
-	}
 	var result Error
-	unwrapped := top.Unwrap()
-	if e, ok := unwrapped.(Error); ok { //nolint:errorlint
-		result = e
-	} else {
-		result = top
-	}
-	for unwrapped != nil {
-		if e, ok := unwrapped.(Error); ok { //nolint:errorlint
+	walk(err, func(err error) bool {
+		if e, ok := err.(Error); ok { //nolint:errorlint
 			result = e
 		}
-		unwrapped = errors.Unwrap(unwrapped)
-	}
-
+		return true
+	})
 	return result
 }
 
 // Cause returns the root cause.
+//
+// When the stack has multiple branches, the root cause of the first branch is returned.
+// Use Causes to get the root causes of every branch.
 func (query) Cause(err error) error {
-	unwrapped := errors.Unwrap(err)
-	for unwrapped != nil {
-		err = unwrapped
-		unwrapped = errors.Unwrap(err)
+	for {
+		children := unwrapAll(err)
+		if len(children) == 0 {
+			return err
+		}
+		err = children[0]
 	}
-	return err
+}
+
+// Causes returns the root causes of every branch in the error stack, in the walk order of Query.Walk.
+//
+// Returns nil if err is nil.
+func (query) Causes(err error) []error {
+	var out []error
+	walk(err, func(err error) bool {
+		if len(unwrapAll(err)) == 0 {
+			out = append(out, err)
+		}
+		return true
+	})
+	return out
 }
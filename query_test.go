@@ -139,6 +139,33 @@ func Test_query_GetHTTPCode(t *testing.T) {
 			},
 			wantCode: 404,
 		},
+		{
+			name: "explicit http code",
+			args: args{
+				err: Global().Bail("foo").Code(40012).HTTPCode(400).Freeze(),
+			},
+			wantCode: 400,
+		},
+		{
+			name: "inner http code is not hidden by outer business code",
+			args: args{
+				err: func() error {
+					err := Global().Bail("foo").Code(40012).HTTPCode(400).Freeze()
+					return Global().Wrap(err).Code(50099).Message("bar").Freeze()
+				}(),
+			},
+			wantCode: 400,
+		},
+		{
+			name: "outer http code wins",
+			args: args{
+				err: func() error {
+					err := Global().Bail("foo").HTTPCode(400).Freeze()
+					return Global().Wrap(err).HTTPCode(409).Freeze()
+				}(),
+			},
+			wantCode: 409,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -319,6 +346,37 @@ func Test_query_GetStack(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "multiple - wrapped maleo errors",
+			args: args{
+				err: WrapFreeze(BailFreeze("foo"), "bar"),
+			},
+			test: func(t *testing.T, stack []ErrorStack) {
+				if len(stack) != 2 {
+					t.Fatalf("expected stack to have 2 elements, got %v", stack)
+				}
+				if stack[0].Error.Error() != "bar: foo" {
+					t.Errorf("expected first stack element to be 'bar: foo', got %q", stack[0].Error.Error())
+				}
+			},
+		},
+		{
+			name: "joined",
+			args: args{
+				err: WrapFreeze(joinError{BailFreeze("foo"), errors.New("plain"), WrapFreeze(BailFreeze("baz"), "bar")}, "join"),
+			},
+			test: func(t *testing.T, stack []ErrorStack) {
+				if len(stack) != 4 {
+					t.Fatalf("expected stack to have 4 elements, got %v", stack)
+				}
+				want := []string{"join", "foo", "bar", "baz"}
+				for i, w := range want {
+					if got := stack[i].Error.(Error).Message(); got != w { //nolint:errorlint
+						t.Errorf("expected stack element %d to be %q, got %q", i, w, got)
+					}
+				}
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -434,6 +492,74 @@ func Test_query_GetMessage(t *testing.T) {
 	}
 }
 
+func Test_query_GetPublicMessage(t *testing.T) {
+	type args struct {
+		err error
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantMessage string
+	}{
+		{
+			name: "nil",
+			args: args{
+				err: nil,
+			},
+			wantMessage: "",
+		},
+		{
+			name: "no public message",
+			args: args{
+				err: Global().Bail("foo").Freeze(),
+			},
+			wantMessage: "",
+		},
+		{
+			name: "single",
+			args: args{
+				err: Global().Bail("foo").PublicMessage("bar %d", 1).Freeze(),
+			},
+			wantMessage: "bar 1",
+		},
+		{
+			name: "inner public message is found",
+			args: args{
+				err: func() error {
+					err := Global().Bail("foo").PublicMessage("bar").Freeze()
+					return Global().Wrap(err).Message("baz").Freeze()
+				}(),
+			},
+			wantMessage: "bar",
+		},
+		{
+			name: "outer public message wins",
+			args: args{
+				err: func() error {
+					err := Global().Bail("foo").PublicMessage("bar").Freeze()
+					return Global().Wrap(err).PublicMessage("baz").Freeze()
+				}(),
+			},
+			wantMessage: "baz",
+		},
+		{
+			name: "wrapped by other error",
+			args: args{
+				err: fmt.Errorf("foo: %w", Global().Bail("bar").PublicMessage("baz").Freeze()),
+			},
+			wantMessage: "baz",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qu := query{}
+			if gotMessage := qu.GetPublicMessage(tt.args.err); gotMessage != tt.wantMessage {
+				t.Errorf("GetPublicMessage() = %v, want %v", gotMessage, tt.wantMessage)
+			}
+		})
+	}
+}
+
 func Test_query_BottomError(t *testing.T) {
 	type args struct {
 		err error
@@ -523,3 +649,86 @@ func Test_query_Cause(t *testing.T) {
 		})
 	}
 }
+
+// joinError mimics errors.Join without requiring go1.20.
+type joinError []error
+
+func (j joinError) Error() string {
+	s := make([]string, 0, len(j))
+	for _, err := range j {
+		if err != nil {
+			s = append(s, err.Error())
+		}
+	}
+	return strings.Join(s, "\n")
+}
+
+func (j joinError) Unwrap() []error {
+	return j
+}
+
+func Test_query_Walk(t *testing.T) {
+	a := errors.New("a")
+	b := errors.New("b")
+	c := errors.New("c")
+	err := fmt.Errorf("top: %w", joinError{a, joinError{b, nil}, c})
+
+	var visited []error
+	Query.Walk(err, func(err error) bool {
+		visited = append(visited, err)
+		return true
+	})
+	if len(visited) != 6 {
+		t.Fatalf("expected 6 visited errors, got %d: %v", len(visited), visited)
+	}
+	if visited[2] != a || visited[4] != b || visited[5] != c {
+		t.Errorf("unexpected walk order: %v", visited)
+	}
+
+	var count int
+	Query.Walk(err, func(err error) bool {
+		count++
+		return err != a
+	})
+	if count != 3 {
+		t.Errorf("expected walk to stop after 3 errors, got %d", count)
+	}
+
+	Query.Walk(nil, func(err error) bool {
+		t.Errorf("nil error should not be visited")
+		return true
+	})
+}
+
+func Test_query_Joined(t *testing.T) {
+	a := errors.New("a")
+	b := BailFreeze("b")
+	c := WrapFreeze(errors.New("c"), "wrap c")
+	err := joinError{a, b, c}
+
+	if got := Query.GetCodeHint(joinError{a, Bail("coded").Code(400).Freeze()}); got != 400 {
+		t.Errorf("Query.GetCodeHint() = %v, want %v", got, 400)
+	}
+	if got := Query.TopError(err); got != b {
+		t.Errorf("Query.TopError() = %v, want %v", got, b)
+	}
+	if got := Query.BottomError(err); got != c {
+		t.Errorf("Query.BottomError() = %v, want %v", got, c)
+	}
+	if got := Query.CollectErrors(err); len(got) != 2 {
+		t.Errorf("Query.CollectErrors() = %v, want 2 errors", got)
+	}
+	if got := Query.Cause(err); got != a {
+		t.Errorf("Query.Cause() = %v, want %v", got, a)
+	}
+	causes := Query.Causes(err)
+	if len(causes) != 3 {
+		t.Fatalf("Query.Causes() = %v, want 3 errors", causes)
+	}
+	if causes[0] != a || causes[1].Error() != "b" || causes[2].Error() != "c" {
+		t.Errorf("Query.Causes() = %v, want [a b c]", causes)
+	}
+	if got := Query.Causes(nil); got != nil {
+		t.Errorf("Query.Causes(nil) = %v, want nil", got)
+	}
+}
@@ -0,0 +1,184 @@
+package maleo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strings"
+)
+
+// PanicError is the origin error of the Error created by Recover and Go from a recovered panic.
+type PanicError struct {
+	// Value is the value given to panic.
+	Value any
+	// Stack is the full stack trace of the panicking goroutine, as formatted by runtime/debug.Stack.
+	Stack []byte
+	// PanicCaller is the location where the panic happened.
+	PanicCaller Caller
+}
+
+func (p *PanicError) Error() string {
+	if err, ok := p.Value.(error); ok {
+		return "panic: " + err.Error()
+	}
+	return fmt.Sprintf("panic: %v", p.Value)
+}
+
+// Unwrap returns the panic value if it's an error.
+func (p *PanicError) Unwrap() error {
+	err, _ := p.Value.(error)
+	return err
+}
+
+// Caller implements CallerHint. Returns the location where the panic happened.
+func (p *PanicError) Caller() Caller {
+	return p.PanicCaller
+}
+
+func (p *PanicError) MarshalJSON() ([]byte, error) {
+	var value any = p.Value
+	if err, ok := p.Value.(error); ok {
+		value = richJsonError{err}
+	}
+	b := &bytes.Buffer{}
+	enc := json.NewEncoder(b)
+	enc.SetEscapeHTML(false)
+	err := enc.Encode(struct {
+		Panic  any      `json:"panic"`
+		Caller Caller   `json:"caller,omitempty"`
+		Stack  []string `json:"stack,omitempty"`
+	}{value, p.PanicCaller, p.stackLines()})
+	if err != nil {
+		// The panic value is not JSON friendly, so we fall back to the printed value.
+		b.Reset()
+		err = enc.Encode(struct {
+			Panic  string   `json:"panic"`
+			Caller Caller   `json:"caller,omitempty"`
+			Stack  []string `json:"stack,omitempty"`
+		}{fmt.Sprint(p.Value), p.PanicCaller, p.stackLines()})
+	}
+	return b.Bytes(), err
+}
+
+func (p *PanicError) stackLines() []string {
+	s := strings.TrimSpace(string(p.Stack))
+	if s == "" {
+		return nil
+	}
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	return lines
+}
+
+/*
+Recover recovers from a panic and turns it into a PanicLevel Error, which is then logged by the Maleo instance
+attached to ctx, or the Global instance if there is none.
+
+Recover must be called directly by defer, otherwise the panic is not recovered.
+
+Example:
+
+	func worker(ctx context.Context) {
+		defer maleo.Recover(ctx, maleo.Option.Recover().Notify())
+		// ...
+	}
+
+The created Error has PanicError as origin, which holds the panic value and the full stack of the panicking goroutine.
+*/
+func Recover(ctx context.Context, opts ...RecoverOption) {
+	if v := recover(); v != nil {
+		handlePanic(ctx, v, nil, opts)
+	}
+}
+
+/*
+Go runs fn in a new goroutine and recovers any panic from it, like Recover does.
+
+The created Error has the location where Go is called as Caller, so the spawn site of the goroutine is known,
+while the location of the panic is kept in the PanicError origin.
+*/
+func Go(ctx context.Context, fn func(ctx context.Context), opts ...RecoverOption) {
+	spawn := GetCaller(2)
+	go func() {
+		defer func() {
+			if v := recover(); v != nil {
+				handlePanic(ctx, v, spawn, opts)
+			}
+		}()
+		fn(ctx)
+	}()
+}
+
+// NewPanicError creates a PanicError from a recovered panic value, for integrations that recover panics themselves.
+//
+// NewPanicError must be called in the deferred function that recovers the panic,
+// otherwise the stack and the location of the panic are lost.
+func NewPanicError(v any) *PanicError {
+	return &PanicError{Value: v, Stack: debug.Stack(), PanicCaller: panicCaller()}
+}
+
+func handlePanic(ctx context.Context, v any, spawn Caller, opts []RecoverOption) {
+	pe := NewPanicError(v)
+	if spawn == nil {
+		spawn = pe.PanicCaller
+	}
+	params := &RecoverParameters{}
+	for _, opt := range opts {
+		opt.Apply(params)
+	}
+	m := MaleoFromContext(ctx)
+	if m == nil {
+		m = Global()
+	}
+	var msgAndArgs []any
+	if params.Message != "" {
+		msgAndArgs = []any{params.Message}
+	}
+	builder := m.constructError(&ErrorConstructorContext{
+		Err:            pe,
+		Caller:         spawn,
+		Maleo:          m,
+		MessageAndArgs: msgAndArgs,
+	}).Level(PanicLevel)
+	if params.Code != 0 {
+		builder = builder.Code(params.Code)
+	}
+	if len(params.Context) > 0 {
+		builder = builder.Context(params.Context...)
+	}
+	err := builder.Freeze()
+	m.LogError(ctx, err)
+	if params.Notify {
+		m.NotifyError(ctx, err, params.MessageOptions...)
+	}
+	if params.Callback != nil {
+		params.Callback(ctx, err)
+	}
+}
+
+// panicCaller looks up the location of the panic, which is the first non runtime frame after runtime.gopanic.
+//
+// Runtime errors like nil pointer dereference have extra runtime frames between runtime.gopanic and the panic location.
+func panicCaller() Caller {
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	var afterPanic bool
+	for {
+		frame, more := frames.Next()
+		if afterPanic && !strings.HasPrefix(frame.Function, "runtime.") {
+			return &caller{pc: frame.PC, file: frame.File, line: frame.Line}
+		}
+		if frame.Function == "runtime.gopanic" {
+			afterPanic = true
+		}
+		if !more {
+			return &caller{}
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package maleo
+
+import (
+	"context"
+	"fmt"
+)
+
+type RecoverParameters struct {
+	// Message overrides the message of the created Error. Defaults to the summary of the panic.
+	Message string
+	// Code overrides the code of the created Error. Defaults to 500.
+	Code int
+	// Context is added to the created Error.
+	Context []any
+	// Notify sends the created Error to the Messengers when true.
+	Notify bool
+	// MessageOptions are the options used when the created Error is sent to the Messengers.
+	MessageOptions []MessageOption
+	// Callback is called with the created Error after it is logged and notified.
+	Callback func(ctx context.Context, err Error)
+}
+
+type RecoverOption interface {
+	Apply(*RecoverParameters)
+}
+
+type (
+	RecoverOptionBuilder []RecoverOption
+	RecoverOptionFunc    func(*RecoverParameters)
+)
+
+func (r RecoverOptionFunc) Apply(parameters *RecoverParameters) {
+	r(parameters)
+}
+
+func (r RecoverOptionBuilder) Apply(parameters *RecoverParameters) {
+	for _, opt := range r {
+		opt.Apply(parameters)
+	}
+}
+
+// Message overrides the message of the Error created from the panic.
+//
+// If args are not empty, msg will be fed into fmt.Sprintf along with the args.
+func (r RecoverOptionBuilder) Message(msg string, args ...any) RecoverOptionBuilder {
+	if len(args) > 0 {
+		msg = fmt.Sprintf(msg, args...)
+	}
+	return append(r, RecoverOptionFunc(func(p *RecoverParameters) {
+		p.Message = msg
+	}))
+}
+
+// Code overrides the code of the Error created from the panic.
+func (r RecoverOptionBuilder) Code(code int) RecoverOptionBuilder {
+	return append(r, RecoverOptionFunc(func(p *RecoverParameters) {
+		p.Code = code
+	}))
+}
+
+// Context adds context to the Error created from the panic.
+func (r RecoverOptionBuilder) Context(ctx ...any) RecoverOptionBuilder {
+	return append(r, RecoverOptionFunc(func(p *RecoverParameters) {
+		p.Context = append(p.Context, ctx...)
+	}))
+}
+
+// Notify sends the Error created from the panic to the Messengers, using the given MessageOption.
+func (r RecoverOptionBuilder) Notify(opts ...MessageOption) RecoverOptionBuilder {
+	return append(r, RecoverOptionFunc(func(p *RecoverParameters) {
+		p.Notify = true
+		p.MessageOptions = append(p.MessageOptions, opts...)
+	}))
+}
+
+// Callback sets a function to be called with the Error created from the panic, after the Error is logged and notified.
+//
+// Useful to pass the Error on, e.g. to an error channel or to mark a job as failed.
+func (r RecoverOptionBuilder) Callback(f func(ctx context.Context, err Error)) RecoverOptionBuilder {
+	return append(r, RecoverOptionFunc(func(p *RecoverParameters) {
+		p.Callback = f
+	}))
+}
@@ -0,0 +1,119 @@
+package maleo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRecover(t *testing.T) {
+	mal, logger := NewTestingMaleo()
+	messenger := &countingMessenger{name: "counting"}
+	mal.Register(messenger)
+	ctx := ContextWithMaleo(context.Background(), mal)
+
+	var got Error
+	func() {
+		defer Recover(ctx, Option.Recover().
+			Code(503).
+			Context("job", "sync").
+			Notify().
+			Callback(func(ctx context.Context, err Error) { got = err }),
+		)
+		panic("boom")
+	}()
+	_ = mal.Wait(ctx)
+
+	if got == nil {
+		t.Fatal("expected callback to be called with the recovered error")
+	}
+	if got.Level() != PanicLevel {
+		t.Errorf("Level() = %v, want %v", got.Level(), PanicLevel)
+	}
+	if got.Code() != 503 {
+		t.Errorf("Code() = %v, want %v", got.Code(), 503)
+	}
+	if got.Message() != "panic: boom" {
+		t.Errorf("Message() = %q, want %q", got.Message(), "panic: boom")
+	}
+	var pe *PanicError
+	if !errors.As(got, &pe) {
+		t.Fatalf("expected error to contain *PanicError, got %T", got.Unwrap())
+	}
+	if pe.Value != "boom" {
+		t.Errorf("PanicError.Value = %v, want %v", pe.Value, "boom")
+	}
+	if !strings.Contains(string(pe.Stack), "maleo.TestRecover") {
+		t.Errorf("PanicError.Stack does not contain the panicking function: %s", pe.Stack)
+	}
+	if pe.Caller().ShortName() != "maleo.TestRecover.func1" {
+		t.Errorf("PanicError.Caller() = %v, want maleo.TestRecover.func1", pe.Caller().ShortName())
+	}
+	if messenger.count != 1 {
+		t.Errorf("messenger count = %v, want %v", messenger.count, 1)
+	}
+
+	var out map[string]any
+	if err := json.Unmarshal(logger.Bytes(), &out); err != nil {
+		t.Fatalf("failed to unmarshal log output: %v: %s", err, logger.String())
+	}
+	if out["level"] != "panic" {
+		t.Errorf("logged level = %v, want %v", out["level"], "panic")
+	}
+	origin, _ := out["error"].(map[string]any)
+	if origin["panic"] != "boom" {
+		t.Errorf("logged error.panic = %v, want %v", origin["panic"], "boom")
+	}
+	if stack, _ := origin["stack"].([]any); len(stack) == 0 {
+		t.Errorf("logged error.stack should not be empty")
+	}
+}
+
+func TestRecover_RuntimeError(t *testing.T) {
+	mal, _ := NewTestingMaleo()
+	ctx := ContextWithMaleo(context.Background(), mal)
+
+	var got Error
+	func() {
+		defer Recover(ctx, Option.Recover().Callback(func(ctx context.Context, err Error) { got = err }))
+		var m map[string]int
+		m["boom"] = 1
+	}()
+	if got == nil {
+		t.Fatal("expected callback to be called with the recovered error")
+	}
+	var pe *PanicError
+	if !errors.As(got, &pe) {
+		t.Fatalf("expected error to contain *PanicError, got %T", got.Unwrap())
+	}
+	if !strings.HasSuffix(pe.Caller().File(), "recover_test.go") {
+		t.Errorf("PanicError.Caller().File() = %v, want recover_test.go", pe.Caller().File())
+	}
+	var rerr interface{ RuntimeError() }
+	if !errors.As(got, &rerr) {
+		t.Errorf("expected the runtime error to be unwrapped from PanicError")
+	}
+}
+
+func TestGo(t *testing.T) {
+	mal, _ := NewTestingMaleo()
+	ctx := ContextWithMaleo(context.Background(), mal)
+
+	done := make(chan Error, 1)
+	Go(ctx, func(ctx context.Context) {
+		panic(errors.New("boom"))
+	}, Option.Recover().Message("worker crashed").Callback(func(ctx context.Context, err Error) { done <- err }))
+	got := <-done
+
+	if got.Message() != "worker crashed" {
+		t.Errorf("Message() = %q, want %q", got.Message(), "worker crashed")
+	}
+	if got.Caller().ShortName() != "maleo.TestGo" {
+		t.Errorf("Caller() = %v, want the spawn site maleo.TestGo", got.Caller().ShortName())
+	}
+	if got.Error() != "worker crashed: panic: boom" {
+		t.Errorf("Error() = %q, want %q", got.Error(), "worker crashed: panic: boom")
+	}
+}
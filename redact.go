@@ -0,0 +1,540 @@
+package maleo
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"path"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Redactor masks sensitive data in the context values of Entries and Errors before they are logged or notified.
+//
+// Redact must not modify the value in place, and should return a value of the same type when possible.
+type Redactor interface {
+	Redact(value any) any
+}
+
+type RedactorFunc func(value any) any
+
+func (f RedactorFunc) Redact(value any) any {
+	return f(value)
+}
+
+// NoopRedactor returns the values as is. The default Redactor of the Engine.
+type NoopRedactor struct{}
+
+func (NoopRedactor) Redact(value any) any {
+	return value
+}
+
+var (
+	// RedactJWT matches JSON Web Tokens.
+	RedactJWT = regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+	// RedactBearer matches the token of bearer authorization values, e.g. "Bearer abc123". Only the token is masked.
+	RedactBearer = regexp.MustCompile(`(?i)\bbearer\s+([A-Za-z0-9\-._~+/]+=*)`)
+	// RedactPAN matches card numbers of 13 to 19 digits, optionally separated by spaces or dashes.
+	RedactPAN = regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`)
+)
+
+// DefaultRedactKeys are the key patterns added by RedactOptionBuilder.Defaults.
+var DefaultRedactKeys = []string{
+	"*password*",
+	"*passwd*",
+	"*secret*",
+	"*token*",
+	"*api_key*",
+	"*apikey*",
+	"*api-key*",
+	"authorization",
+	"proxy-authorization",
+	"cookie",
+	"set-cookie",
+	"cvv",
+	"cvc",
+}
+
+type valueRule struct {
+	pattern *regexp.Regexp
+	// valid filters the matches, e.g. to mask only card numbers that pass the Luhn check.
+	valid func(match string) bool
+}
+
+type redactor struct {
+	keys   []string
+	paths  [][]string
+	values []valueRule
+	mask   string
+}
+
+/*
+NewRedactor creates a Redactor from the rules set by the options. Without rules, the values are returned as is.
+
+Example:
+
+	redactor := maleo.NewRedactor(maleo.Option.Redact().
+		Defaults().
+		Keys("*ssn*").
+		Paths("$.request.body.card.number").
+		Mask("***"),
+	)
+	maleo.Global().SetEngine(maleo.NewEngine(maleo.Option.Engine().Redactor(redactor)))
+*/
+func NewRedactor(opts ...RedactOption) Redactor {
+	params := &RedactParameters{Mask: "[REDACTED]"}
+	for _, opt := range opts {
+		opt.Apply(params)
+	}
+	r := &redactor{mask: params.Mask}
+	for _, key := range params.Keys {
+		r.keys = append(r.keys, strings.ToLower(key))
+	}
+	for _, p := range params.Paths {
+		r.paths = append(r.paths, parseRedactPath(p))
+	}
+	for _, re := range params.Values {
+		r.values = append(r.values, valueRule{pattern: re})
+	}
+	if params.PAN {
+		r.values = append(r.values, valueRule{pattern: RedactPAN, valid: luhn})
+	}
+	return r
+}
+
+// parseRedactPath splits JSON paths like "$.items[*].number" into ["items", "[*]", "number"].
+func parseRedactPath(p string) []string {
+	p = strings.TrimPrefix(strings.TrimPrefix(p, "$"), ".")
+	var segments []string
+	for _, part := range strings.Split(p, ".") {
+		for {
+			i := strings.IndexByte(part, '[')
+			if i < 0 {
+				break
+			}
+			if i > 0 {
+				segments = append(segments, part[:i])
+			}
+			j := strings.IndexByte(part, ']')
+			if j < i {
+				break
+			}
+			segments = append(segments, part[i:j+1])
+			part = part[j+1:]
+		}
+		if part != "" {
+			segments = append(segments, part)
+		}
+	}
+	return segments
+}
+
+func (r *redactor) Redact(value any) any {
+	if len(r.keys) == 0 && len(r.paths) == 0 && len(r.values) == 0 {
+		return value
+	}
+	out, _ := r.walk(value, nil)
+	return out
+}
+
+func (r *redactor) matchKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, pattern := range r.keys {
+		if ok, _ := path.Match(pattern, key); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *redactor) matchPath(current []string) bool {
+	for _, rule := range r.paths {
+		if len(rule) != len(current) {
+			continue
+		}
+		match := true
+		for i, segment := range rule {
+			switch {
+			case segment == "*" || segment == current[i]:
+			case segment == "[*]" && strings.HasPrefix(current[i], "["):
+			default:
+				match = false
+			}
+			if !match {
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *redactor) sensitive(key string, current []string) bool {
+	return r.matchKey(key) || r.matchPath(current)
+}
+
+func (r *redactor) redactString(s string) (string, bool) {
+	changed := false
+	for _, rule := range r.values {
+		s = rule.pattern.ReplaceAllStringFunc(s, func(match string) string {
+			if rule.valid != nil && !rule.valid(match) {
+				return match
+			}
+			changed = true
+			// Only the first group is masked if the pattern has one, e.g. the token of "Bearer <token>".
+			if rule.pattern.NumSubexp() > 0 {
+				sub := rule.pattern.FindStringSubmatchIndex(match)
+				if len(sub) >= 4 && sub[2] >= 0 {
+					return match[:sub[2]] + r.mask + match[sub[3]:]
+				}
+			}
+			return r.mask
+		})
+	}
+	return s, changed
+}
+
+// walk returns the redacted value and whether anything is redacted. Values without redactions are returned as is.
+func (r *redactor) walk(value any, current []string) (any, bool) {
+	switch v := value.(type) {
+	case nil:
+		return nil, false
+	case string:
+		return r.redactString(v)
+	case json.RawMessage:
+		if out, ok := r.walkJSON(v, current); ok {
+			return json.RawMessage(out), true
+		}
+		return v, false
+	case []byte:
+		if json.Valid(v) {
+			if out, ok := r.walkJSON(v, current); ok {
+				return out, true
+			}
+			return v, false
+		}
+		if s, ok := r.redactString(string(v)); ok {
+			return []byte(s), true
+		}
+		return v, false
+	case json.Number:
+		return v, false
+	case error:
+		// maleo Errors redact their own context. Other errors are rendered by their messages.
+		return v, false
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.String:
+		if s, ok := r.redactString(rv.String()); ok {
+			return reflect.ValueOf(s).Convert(rv.Type()).Interface(), true
+		}
+	case reflect.Map:
+		return r.walkMap(rv, current)
+	case reflect.Slice, reflect.Array:
+		return r.walkSlice(rv, current)
+	case reflect.Struct, reflect.Pointer, reflect.Interface:
+		if rv.Kind() != reflect.Struct && rv.IsNil() {
+			return value, false
+		}
+		b, err := json.Marshal(value)
+		if err != nil {
+			return value, false
+		}
+		if out, ok := r.walkJSON(b, current); ok {
+			return json.RawMessage(out), true
+		}
+	}
+	return value, false
+}
+
+func (r *redactor) walkJSON(b []byte, current []string) ([]byte, bool) {
+	var v any
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return b, false
+	}
+	out, changed := r.walk(v, current)
+	if !changed {
+		return b, false
+	}
+	res, err := json.Marshal(out)
+	if err != nil {
+		return b, false
+	}
+	return res, true
+}
+
+func (r *redactor) walkMap(rv reflect.Value, current []string) (any, bool) {
+	elem := rv.Type().Elem()
+	keys := make([]reflect.Value, 0, rv.Len())
+	values := make([]any, 0, rv.Len())
+	changed, fits := false, true
+	iter := rv.MapRange()
+	for iter.Next() {
+		key := mapKeyName(iter.Key())
+		next := append(current[:len(current):len(current)], key)
+		var value any = iter.Value().Interface()
+		if r.sensitive(key, next) {
+			var ok bool
+			value, ok = r.maskValue(elem, iter.Value())
+			changed, fits = true, fits && ok
+		} else if redacted, ok := r.walk(value, next); ok {
+			value = redacted
+			changed = true
+			fits = fits && fitsType(redacted, elem)
+		}
+		keys = append(keys, iter.Key())
+		values = append(values, value)
+	}
+	if !changed {
+		return rv.Interface(), false
+	}
+	// Typed maps that cannot hold the redacted values, e.g. map[string]User, become map[string]any, or map[K]any for
+	// other key types, so the keys are kept as they are.
+	if !fits {
+		if rv.Type().Key().Kind() == reflect.String {
+			out := make(map[string]any, len(keys))
+			for i, key := range keys {
+				out[key.String()] = values[i]
+			}
+			return out, true
+		}
+		out := reflect.MakeMapWithSize(reflect.MapOf(rv.Type().Key(), reflect.TypeOf((*any)(nil)).Elem()), len(keys))
+		for i, key := range keys {
+			out.SetMapIndex(key, reflect.ValueOf(&values[i]).Elem())
+		}
+		return out.Interface(), true
+	}
+	out := reflect.MakeMapWithSize(rv.Type(), len(keys))
+	for i, key := range keys {
+		out.SetMapIndex(key, convertValue(values[i], elem))
+	}
+	return out.Interface(), true
+}
+
+// mapKeyName returns the name of a map key to match against the key patterns. Keys that are not strings are named by
+// their encoding.TextMarshaler, like encoding/json does, or by fmt.Sprint, which uses fmt.Stringer.
+func mapKeyName(key reflect.Value) string {
+	if key.Kind() == reflect.String {
+		return key.String()
+	}
+	if tm, ok := key.Interface().(encoding.TextMarshaler); ok {
+		if b, err := tm.MarshalText(); err == nil {
+			return string(b)
+		}
+	}
+	return fmt.Sprint(key.Interface())
+}
+
+func (r *redactor) walkSlice(rv reflect.Value, current []string) (any, bool) {
+	if rv.Kind() == reflect.Slice && rv.IsNil() {
+		return rv.Interface(), false
+	}
+	elem := rv.Type().Elem()
+	values := make([]any, rv.Len())
+	changed, fits := false, true
+	for i := 0; i < rv.Len(); i++ {
+		next := append(current[:len(current):len(current)], "["+strconv.Itoa(i)+"]")
+		var value any = rv.Index(i).Interface()
+		if r.matchPath(next) {
+			var ok bool
+			value, ok = r.maskValue(elem, rv.Index(i))
+			changed, fits = true, fits && ok
+		} else if redacted, ok := r.walk(value, next); ok {
+			value = redacted
+			changed = true
+			fits = fits && fitsType(redacted, elem)
+		}
+		values[i] = value
+	}
+	if !changed {
+		return rv.Interface(), false
+	}
+	// Typed slices that cannot hold the redacted values, e.g. []User, become []any.
+	if !fits {
+		return values, true
+	}
+	var out reflect.Value
+	if rv.Kind() == reflect.Slice {
+		out = reflect.MakeSlice(rv.Type(), rv.Len(), rv.Len())
+	} else {
+		out = reflect.New(rv.Type()).Elem()
+	}
+	for i, value := range values {
+		out.Index(i).Set(convertValue(value, elem))
+	}
+	return out.Interface(), true
+}
+
+// maskValue returns the mask as a value of type typ. Slices of strings, like the values of http.Header, become a
+// single mask. Returns the mask string and false if typ cannot hold the mask, e.g. structs.
+func (r *redactor) maskValue(typ reflect.Type, original reflect.Value) (any, bool) {
+	mask := reflect.ValueOf(r.mask)
+	switch {
+	case mask.Type().AssignableTo(typ):
+		return r.mask, true
+	case mask.Type().ConvertibleTo(typ) && typ.Kind() == reflect.String:
+		return mask.Convert(typ).Interface(), true
+	case typ.Kind() == reflect.Slice && typ.Elem().Kind() == reflect.String:
+		out := reflect.MakeSlice(typ, 0, 1)
+		if original.Len() > 0 {
+			out = reflect.Append(out, mask.Convert(typ.Elem()))
+		}
+		return out.Interface(), true
+	}
+	return r.mask, false
+}
+
+// fitsType returns true if the redacted value can be stored as typ, e.g. json.RawMessage of a redacted struct stored in
+// an interface.
+func fitsType(redacted any, typ reflect.Type) bool {
+	if redacted == nil {
+		return true
+	}
+	rt := reflect.TypeOf(redacted)
+	return rt.AssignableTo(typ) || (rt.ConvertibleTo(typ) && rt.Kind() == typ.Kind())
+}
+
+// convertValue converts the redacted value to typ. The value must fit typ, see fitsType.
+func convertValue(redacted any, typ reflect.Type) reflect.Value {
+	if redacted == nil {
+		return reflect.Zero(typ)
+	}
+	rv := reflect.ValueOf(redacted)
+	if rv.Type().AssignableTo(typ) {
+		return rv
+	}
+	return rv.Convert(typ)
+}
+
+// luhn returns true if the digits of s pass the Luhn checksum used by card numbers.
+func luhn(s string) bool {
+	sum, n := 0, 0
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if n%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		n++
+	}
+	return n > 0 && sum%10 == 0
+}
+
+// Redact masks the sensitive data in value with the Redactor of the engine.
+//
+// Entries and Errors redact their context values already. Use this for data that is logged by other means, e.g. in
+// custom hooks.
+func (m *Maleo) Redact(value any) any {
+	return m.redactor().Redact(value)
+}
+
+func (m *Maleo) redacts() bool {
+	if m == nil || m.engine == nil {
+		return false
+	}
+	_, noop := m.redactor().(NoopRedactor)
+	return !noop
+}
+
+// redactContext redacts the context values of Entries and Errors with the Redactor of the engine.
+func (m *Maleo) redactContext(ctx []any) []any {
+	if !m.redacts() || len(ctx) == 0 {
+		return ctx
+	}
+	return redactContext(m.Redact, ctx)
+}
+
+// redactContext redacts the context values with redact. Multiple values are key-value pairs, so the keys are matched
+// against the key patterns too. Keys that are not strings are redacted like values, since they are rendered with
+// fmt.Sprint.
+func redactContext(redact func(value any) any, ctx []any) []any {
+	if len(ctx) == 0 {
+		return ctx
+	}
+	if len(ctx) == 1 {
+		return []any{redact(ctx[0])}
+	}
+	out := make([]any, len(ctx))
+	copy(out, ctx)
+	for i := 0; i < len(out); i += 2 {
+		key, ok := out[i].(string)
+		if !ok {
+			redacted := redact(out[i])
+			if raw, isRaw := redacted.(json.RawMessage); isRaw {
+				redacted = string(raw)
+			}
+			out[i] = redacted
+			key = fmt.Sprint(redacted)
+		}
+		if i+1 == len(out) {
+			break
+		}
+		if f, ok := redact(F{key: out[i+1]}).(F); ok {
+			out[i+1] = f[key]
+		}
+	}
+	return out
+}
+
+func (m *Maleo) redactFields(fields Fields) Fields {
+	if !m.redacts() || len(fields) == 0 {
+		return fields
+	}
+	if f, ok := m.Redact(fields).(Fields); ok {
+		return f
+	}
+	return fields
+}
+
+var (
+	_ MessageContext    = (*redactedMessageContext)(nil)
+	_ ComponentHint     = (*redactedMessageContext)(nil)
+	_ ContextFieldsHint = (*redactedMessageContext)(nil)
+)
+
+// redactedMessageContext redacts the Context and ContextFields of a message for Middleware.Redact.
+type redactedMessageContext struct {
+	MessageContext
+	redact func(value any) any
+}
+
+func (r *redactedMessageContext) Context() []any {
+	return redactContext(r.redact, r.MessageContext.Context())
+}
+
+func (r *redactedMessageContext) ContextFields() Fields {
+	cf, ok := r.MessageContext.(ContextFieldsHint)
+	if !ok {
+		return nil
+	}
+	fields := cf.ContextFields()
+	if len(fields) == 0 {
+		return fields
+	}
+	if f, ok := r.redact(fields).(Fields); ok {
+		return f
+	}
+	return fields
+}
+
+func (r *redactedMessageContext) Component() string {
+	if ch, ok := r.MessageContext.(ComponentHint); ok {
+		return ch.Component()
+	}
+	return ""
+}
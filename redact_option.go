@@ -0,0 +1,88 @@
+package maleo
+
+import "regexp"
+
+type RedactParameters struct {
+	// Keys are case-insensitive glob patterns, matched against map keys and header names, e.g. "*password*".
+	// Values of matching keys are replaced by the mask.
+	Keys []string
+	// Paths are JSON paths of the values to mask, e.g. "$.request.body.card.number" or "$.items[*].token".
+	// "*" matches any key and "[*]" matches any index.
+	Paths []string
+	// Values are patterns matched against string values, e.g. RedactJWT. Matches are replaced by the mask.
+	// If a pattern has a group, only the first group is replaced.
+	Values []*regexp.Regexp
+	// PAN masks card numbers that pass the Luhn check.
+	PAN bool
+	// Mask replaces the redacted values. Defaults to "[REDACTED]".
+	Mask string
+}
+
+type RedactOption interface {
+	Apply(*RedactParameters)
+}
+
+type (
+	RedactOptionBuilder []RedactOption
+	RedactOptionFunc    func(*RedactParameters)
+)
+
+func (r RedactOptionFunc) Apply(parameters *RedactParameters) {
+	r(parameters)
+}
+
+func (r RedactOptionBuilder) Apply(parameters *RedactParameters) {
+	for _, opt := range r {
+		opt.Apply(parameters)
+	}
+}
+
+// Keys adds case-insensitive glob patterns of the keys whose values are masked.
+func (r RedactOptionBuilder) Keys(patterns ...string) RedactOptionBuilder {
+	return append(r, RedactOptionFunc(func(p *RedactParameters) {
+		p.Keys = append(p.Keys, patterns...)
+	}))
+}
+
+// Paths adds JSON paths of the values to mask.
+func (r RedactOptionBuilder) Paths(paths ...string) RedactOptionBuilder {
+	return append(r, RedactOptionFunc(func(p *RedactParameters) {
+		p.Paths = append(p.Paths, paths...)
+	}))
+}
+
+// Values adds patterns matched against string values.
+func (r RedactOptionBuilder) Values(patterns ...*regexp.Regexp) RedactOptionBuilder {
+	return append(r, RedactOptionFunc(func(p *RedactParameters) {
+		p.Values = append(p.Values, patterns...)
+	}))
+}
+
+// JWT masks JSON Web Tokens in string values.
+func (r RedactOptionBuilder) JWT() RedactOptionBuilder {
+	return r.Values(RedactJWT)
+}
+
+// BearerTokens masks the tokens of bearer authorization values in string values.
+func (r RedactOptionBuilder) BearerTokens() RedactOptionBuilder {
+	return r.Values(RedactBearer)
+}
+
+// PAN masks card numbers that pass the Luhn check in string values.
+func (r RedactOptionBuilder) PAN() RedactOptionBuilder {
+	return append(r, RedactOptionFunc(func(p *RedactParameters) {
+		p.PAN = true
+	}))
+}
+
+// Mask sets the string that replaces the redacted values.
+func (r RedactOptionBuilder) Mask(mask string) RedactOptionBuilder {
+	return append(r, RedactOptionFunc(func(p *RedactParameters) {
+		p.Mask = mask
+	}))
+}
+
+// Defaults adds DefaultRedactKeys, and masks JWTs, bearer tokens and card numbers.
+func (r RedactOptionBuilder) Defaults() RedactOptionBuilder {
+	return r.Keys(DefaultRedactKeys...).JWT().BearerTokens().PAN()
+}
@@ -0,0 +1,394 @@
+package maleo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// RouteMatcher decides whether a RouteRule applies to a message.
+type RouteMatcher interface {
+	Match(msg MessageContext) bool
+}
+
+type RouteMatcherFunc func(msg MessageContext) bool
+
+func (f RouteMatcherFunc) Match(msg MessageContext) bool {
+	return f(msg)
+}
+
+// RouteRule routes the messages that match the rule to the Messengers with the given names.
+type RouteRule struct {
+	// Name identifies the rule in RouteExplanation.
+	Name string
+	// Match decides whether the rule applies to the message. Nil Match matches every message.
+	Match RouteMatcher
+	// Messengers are the names of the Messengers the matching messages are sent to.
+	// The Messengers are looked up from both the registered and the benched Messengers.
+	Messengers []string
+	// Only sends the matching messages to the Messengers of this rule only,
+	// dropping the default Messengers and the Messengers added by earlier rules.
+	Only bool
+	// Stop skips the rest of the rules when this rule matches.
+	Stop bool
+}
+
+/*
+Router picks the Messengers of a message with RouteRules, instead of calling Include or Exclude options on every Notify.
+
+Rules are evaluated in order against the MessageContext, after the message options are applied and before the message
+is sent. Every matching rule adds its Messengers to the Messengers of the message.
+
+Example:
+
+	router := maleo.NewRouter(
+		maleo.RouteRule{
+			Name:       "oncall",
+			Match:      maleo.Match.All(maleo.Match.Level(maleo.ErrorLevel), maleo.Match.Environment("prod")),
+			Messengers: []string{"discord-oncall"},
+		},
+		maleo.RouteRule{
+			Name:       "payments",
+			Match:      maleo.Match.KeyPrefix("payment"),
+			Messengers: []string{"discord-payments"},
+		},
+	)
+	m := maleo.New(service, maleo.Option.Init().Router(router))
+*/
+type Router struct {
+	rules []RouteRule
+}
+
+// NewRouter creates a new Router with the given rules.
+func NewRouter(rules ...RouteRule) *Router {
+	return &Router{rules: rules}
+}
+
+// Rules returns the rules of the Router.
+func (r *Router) Rules() []RouteRule {
+	return r.rules
+}
+
+// RouteRuleResult is the result of a single rule in RouteExplanation.
+type RouteRuleResult struct {
+	Name    string `json:"name"`
+	Matched bool   `json:"matched"`
+	// Messengers are the names of the Messengers the rule added. Empty if the rule did not match.
+	Messengers []string `json:"messengers,omitempty"`
+	// Missing are the Messenger names of the rule that are not registered nor benched.
+	Missing []string `json:"missing,omitempty"`
+}
+
+// RouteExplanation shows how the Messengers of a message are picked.
+type RouteExplanation struct {
+	// Rules are the results of the evaluated rules, in order. Rules after a matching rule with Stop are not evaluated.
+	Rules []RouteRuleResult `json:"rules"`
+	// Messengers are the names of the Messengers the message is sent to.
+	Messengers []string `json:"messengers"`
+	// Disabled are the names of the routed Messengers that skip the message because of their level.
+	Disabled []string `json:"disabled,omitempty"`
+	// Dropped is true if the message is dropped by the Hooks of the Engine, so no rules are evaluated.
+	Dropped bool `json:"dropped,omitempty"`
+}
+
+// Matched returns the names of the matching rules.
+func (r RouteExplanation) Matched() []string {
+	var names []string
+	for _, rule := range r.Rules {
+		if rule.Matched {
+			names = append(names, rule.Name)
+		}
+	}
+	return names
+}
+
+// route applies the rules to the Messengers of params.
+func (r *Router) route(msg MessageContext, params *MessageParameters) []RouteRuleResult {
+	results := make([]RouteRuleResult, 0, len(r.rules))
+	messengers := params.Messengers
+	for _, rule := range r.rules {
+		result := RouteRuleResult{Name: rule.Name}
+		if rule.Match == nil || rule.Match.Match(msg) {
+			result.Matched = true
+			var found Messengers
+			found, result.Missing = lookupMessengers(rule.Messengers, params.Messengers, params.Benched)
+			for _, messenger := range found {
+				result.Messengers = append(result.Messengers, messenger.Name())
+			}
+			if rule.Only {
+				messengers = found
+			} else {
+				messengers = appendMissingMessengers(messengers, found)
+			}
+		}
+		results = append(results, result)
+		if result.Matched && rule.Stop {
+			break
+		}
+	}
+	params.Messengers = messengers
+	return results
+}
+
+func lookupMessengers(names []string, sources ...Messengers) (found Messengers, missing []string) {
+	for _, name := range names {
+		var ok bool
+		for _, source := range sources {
+			for _, messenger := range source {
+				if messenger.Name() == name {
+					found = appendMissingMessengers(found, Messengers{messenger})
+					ok = true
+					break
+				}
+			}
+			if ok {
+				break
+			}
+		}
+		if !ok {
+			missing = append(missing, name)
+		}
+	}
+	return found, missing
+}
+
+func appendMissingMessengers(messengers Messengers, add Messengers) Messengers {
+	for _, messenger := range add {
+		var exist bool
+		for _, m := range messengers {
+			if m.Name() == messenger.Name() {
+				exist = true
+				break
+			}
+		}
+		if !exist {
+			messengers = append(messengers, messenger)
+		}
+	}
+	return messengers
+}
+
+type match struct{}
+
+// Match holds the builtin RouteMatchers.
+var Match match
+
+// Level matches messages with level enabled by lvl, e.g. maleo.ErrorLevel matches error level and above.
+func (match) Level(lvl LevelEnabler) RouteMatcher {
+	return RouteMatcherFunc(func(msg MessageContext) bool {
+		return lvl.Enabled(msg.Level())
+	})
+}
+
+// Environment matches messages whose Service environment is one of the given environments.
+func (match) Environment(environments ...string) RouteMatcher {
+	return RouteMatcherFunc(func(msg MessageContext) bool {
+		return containsString(environments, msg.Service().Environment)
+	})
+}
+
+// ServiceName matches messages whose Service name is one of the given names.
+func (match) ServiceName(names ...string) RouteMatcher {
+	return RouteMatcherFunc(func(msg MessageContext) bool {
+		return containsString(names, msg.Service().Name)
+	})
+}
+
+// Code matches messages with one of the given codes.
+func (match) Code(codes ...int) RouteMatcher {
+	return RouteMatcherFunc(func(msg MessageContext) bool {
+		code := msg.Code()
+		for _, c := range codes {
+			if c == code {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// CodeRange matches messages with code between min and max, inclusive.
+func (match) CodeRange(min, max int) RouteMatcher {
+	return RouteMatcherFunc(func(msg MessageContext) bool {
+		code := msg.Code()
+		return code >= min && code <= max
+	})
+}
+
+// KeyPrefix matches messages whose key starts with prefix.
+func (match) KeyPrefix(prefix string) RouteMatcher {
+	return RouteMatcherFunc(func(msg MessageContext) bool {
+		return strings.HasPrefix(msg.Key(), prefix)
+	})
+}
+
+// Component matches messages created by the Maleo instances with one of the given component names.
+// See Maleo.Named.
+func (match) Component(components ...string) RouteMatcher {
+	return RouteMatcherFunc(func(msg MessageContext) bool {
+		ch, ok := msg.(ComponentHint)
+		return ok && containsString(components, ch.Component())
+	})
+}
+
+// All matches messages that match all the given matchers.
+func (match) All(matchers ...RouteMatcher) RouteMatcher {
+	return RouteMatcherFunc(func(msg MessageContext) bool {
+		for _, m := range matchers {
+			if !m.Match(msg) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// Any matches messages that match at least one of the given matchers.
+func (match) Any(matchers ...RouteMatcher) RouteMatcher {
+	return RouteMatcherFunc(func(msg MessageContext) bool {
+		for _, m := range matchers {
+			if m.Match(msg) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Not matches messages that do not match the given matcher.
+func (match) Not(matcher RouteMatcher) RouteMatcher {
+	return RouteMatcherFunc(func(msg MessageContext) bool {
+		return !matcher.Match(msg)
+	})
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// RouteConfig is the declarative form of Router, e.g. to load the routing rules from a configuration file.
+type RouteConfig struct {
+	Rules []RouteRuleConfig `json:"rules" yaml:"rules"`
+}
+
+/*
+RouteRuleConfig is the declarative form of RouteRule. Every criteria that is set must match for the rule to match.
+A rule without criteria matches every message.
+
+Example in YAML:
+
+	rules:
+	  - name: oncall
+	    level: error
+	    environments: [prod]
+	    messengers: [discord-oncall]
+	  - name: client-errors
+	    codes: ["400..499"]
+	    messengers: [discord-low]
+*/
+type RouteRuleConfig struct {
+	Name string `json:"name" yaml:"name"`
+	// Level is the minimum level of the messages, e.g. "error".
+	Level string `json:"level,omitempty" yaml:"level,omitempty"`
+	// Environments are the Service environments of the messages.
+	Environments []string `json:"environments,omitempty" yaml:"environments,omitempty"`
+	// Services are the Service names of the messages.
+	Services []string `json:"services,omitempty" yaml:"services,omitempty"`
+	// Codes are the codes of the messages, either single codes like "503" or inclusive ranges like "400..499".
+	Codes []string `json:"codes,omitempty" yaml:"codes,omitempty"`
+	// KeyPrefix is the prefix of the keys of the messages.
+	KeyPrefix string `json:"key_prefix,omitempty" yaml:"key_prefix,omitempty"`
+	// Components are the component names of the messages.
+	Components []string `json:"components,omitempty" yaml:"components,omitempty"`
+	// Messengers are the names of the Messengers the matching messages are sent to.
+	Messengers []string `json:"messengers" yaml:"messengers"`
+	Only       bool     `json:"only,omitempty" yaml:"only,omitempty"`
+	Stop       bool     `json:"stop,omitempty" yaml:"stop,omitempty"`
+}
+
+// Router creates a Router from the config. Returns error if the config contains invalid levels or codes.
+func (c RouteConfig) Router() (*Router, error) {
+	rules := make([]RouteRule, 0, len(c.Rules))
+	var errs multierror
+	for i, rc := range c.Rules {
+		rule, err := rc.rule()
+		if err != nil {
+			name := rc.Name
+			if name == "" {
+				name = strconv.Itoa(i)
+			}
+			errs = append(errs, fmt.Errorf("route rule %s: %w", name, err))
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return NewRouter(rules...), nil
+}
+
+func (rc RouteRuleConfig) rule() (RouteRule, error) {
+	var matchers []RouteMatcher
+	if rc.Level != "" {
+		lvl, err := ParseLevel(rc.Level)
+		if err != nil {
+			return RouteRule{}, err
+		}
+		matchers = append(matchers, Match.Level(lvl))
+	}
+	if len(rc.Environments) > 0 {
+		matchers = append(matchers, Match.Environment(rc.Environments...))
+	}
+	if len(rc.Services) > 0 {
+		matchers = append(matchers, Match.ServiceName(rc.Services...))
+	}
+	if len(rc.Codes) > 0 {
+		codes := make([]RouteMatcher, 0, len(rc.Codes))
+		for _, code := range rc.Codes {
+			m, err := parseCodeMatcher(code)
+			if err != nil {
+				return RouteRule{}, err
+			}
+			codes = append(codes, m)
+		}
+		matchers = append(matchers, Match.Any(codes...))
+	}
+	if rc.KeyPrefix != "" {
+		matchers = append(matchers, Match.KeyPrefix(rc.KeyPrefix))
+	}
+	if len(rc.Components) > 0 {
+		matchers = append(matchers, Match.Component(rc.Components...))
+	}
+	return RouteRule{
+		Name:       rc.Name,
+		Match:      Match.All(matchers...),
+		Messengers: rc.Messengers,
+		Only:       rc.Only,
+		Stop:       rc.Stop,
+	}, nil
+}
+
+func parseCodeMatcher(s string) (RouteMatcher, error) {
+	if from, to, ok := strings.Cut(s, ".."); ok {
+		min, err := strconv.Atoi(strings.TrimSpace(from))
+		if err != nil {
+			return nil, fmt.Errorf("invalid code range %q", s)
+		}
+		max, err := strconv.Atoi(strings.TrimSpace(to))
+		if err != nil {
+			return nil, fmt.Errorf("invalid code range %q", s)
+		}
+		return Match.CodeRange(min, max), nil
+	}
+	code, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil {
+		return nil, fmt.Errorf("invalid code %q", s)
+	}
+	return Match.Code(code), nil
+}
@@ -0,0 +1,150 @@
+package maleo
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestRouter(t *testing.T) {
+	tests := []struct {
+		name       string
+		service    Service
+		notify     func(m *Maleo)
+		wantCounts map[string]int
+	}{
+		{
+			name:    "error in prod goes to oncall",
+			service: Service{Name: "api", Environment: "prod"},
+			notify: func(m *Maleo) {
+				_ = m.Bail("boom").Notify(context.Background())
+			},
+			wantCounts: map[string]int{"default": 1, "oncall": 1, "low": 0, "payments": 0},
+		},
+		{
+			name:    "error in staging stays in default",
+			service: Service{Name: "api", Environment: "staging"},
+			notify: func(m *Maleo) {
+				_ = m.Bail("boom").Notify(context.Background())
+			},
+			wantCounts: map[string]int{"default": 1, "oncall": 0, "low": 0, "payments": 0},
+		},
+		{
+			name:    "client error goes to low",
+			service: Service{Name: "api", Environment: "staging"},
+			notify: func(m *Maleo) {
+				_ = m.Bail("bad request").Code(422).Notify(context.Background())
+			},
+			wantCounts: map[string]int{"default": 1, "oncall": 0, "low": 1, "payments": 0},
+		},
+		{
+			name:    "payment key goes to payments only and stops",
+			service: Service{Name: "api", Environment: "prod"},
+			notify: func(m *Maleo) {
+				m.NewEntry("refund").Key("payment.refund").Level(ErrorLevel).Notify(context.Background())
+			},
+			wantCounts: map[string]int{"default": 0, "oncall": 0, "low": 0, "payments": 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := RouteConfig{Rules: []RouteRuleConfig{
+				{Name: "payments", KeyPrefix: "payment", Messengers: []string{"payments"}, Only: true, Stop: true},
+				{Name: "oncall", Level: "error", Environments: []string{"prod"}, Messengers: []string{"oncall"}},
+				{Name: "client-errors", Codes: []string{"400..499"}, Messengers: []string{"low"}},
+			}}
+			router, err := config.Router()
+			if err != nil {
+				t.Fatalf("RouteConfig.Router() error = %v", err)
+			}
+			messengers := map[string]*countingMessenger{}
+			for _, name := range []string{"default", "oncall", "low", "payments"} {
+				messengers[name] = &countingMessenger{name: name}
+			}
+			m := New(tt.service, Option.Init().Router(router))
+			m.Register(messengers["default"])
+			m.RegisterBenched(messengers["oncall"], messengers["low"], messengers["payments"])
+
+			tt.notify(m)
+			for name, want := range tt.wantCounts {
+				if got := messengers[name].count; got != want {
+					t.Errorf("messenger %q received %d messages, want %d", name, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestMaleo_ExplainNotify(t *testing.T) {
+	router := NewRouter(
+		RouteRule{Name: "errors", Match: Match.Level(ErrorLevel), Messengers: []string{"oncall", "unknown"}},
+		RouteRule{Name: "stop", Match: Match.Component("db"), Messengers: []string{"low"}, Stop: true},
+		RouteRule{Name: "never", Messengers: []string{"low"}},
+	)
+	m := New(Service{Name: "api"}, Option.Init().Router(router).MessengerLevel("low", ErrorLevel))
+	m.Register(&countingMessenger{name: "default"})
+	oncall := &countingMessenger{name: "oncall"}
+	m.RegisterBenched(oncall, &countingMessenger{name: "low"})
+
+	db := m.Named("db")
+	got := db.ExplainNotify(context.Background(), db.NewEntry("slow query").Level(WarnLevel).Freeze())
+	want := RouteExplanation{
+		Rules: []RouteRuleResult{
+			{Name: "errors", Matched: false},
+			{Name: "stop", Matched: true, Messengers: []string{"low"}},
+		},
+		Messengers: []string{"default"},
+		Disabled:   []string{"low"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ExplainNotify() = %+v, want %+v", got, want)
+	}
+
+	got = m.ExplainNotifyError(context.Background(), m.BailFreeze("boom"))
+	want = RouteExplanation{
+		Rules: []RouteRuleResult{
+			{Name: "errors", Matched: true, Messengers: []string{"oncall"}, Missing: []string{"unknown"}},
+			{Name: "stop", Matched: false},
+			{Name: "never", Matched: true, Messengers: []string{"low"}},
+		},
+		Messengers: []string{"default", "oncall", "low"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ExplainNotifyError() = %+v, want %+v", got, want)
+	}
+	if matched := got.Matched(); !reflect.DeepEqual(matched, []string{"errors", "never"}) {
+		t.Errorf("Matched() = %v, want %v", matched, []string{"errors", "never"})
+	}
+	if oncall.count != 0 {
+		t.Errorf("ExplainNotifyError() must not send messages, oncall received %d", oncall.count)
+	}
+}
+
+func TestRouteConfig_Router(t *testing.T) {
+	_, err := RouteConfig{Rules: []RouteRuleConfig{
+		{Name: "bad-level", Level: "loud"},
+		{Codes: []string{"4xx"}},
+	}}.Router()
+	if err == nil {
+		t.Fatal("RouteConfig.Router() expected error")
+	}
+	want := `1. route rule bad-level: maleo: unknown level "loud"; 2. route rule 1: invalid code "4xx"`
+	if err.Error() != want {
+		t.Errorf("RouteConfig.Router() error = %v, want %v", err, want)
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	for _, lvl := range []Level{DebugLevel, InfoLevel, WarnLevel, ErrorLevel, FatalLevel, PanicLevel} {
+		got, err := ParseLevel(lvl.String())
+		if err != nil || got != lvl {
+			t.Errorf("ParseLevel(%q) = %v, %v, want %v", lvl.String(), got, err, lvl)
+		}
+	}
+	if got, err := ParseLevel(" WARNING "); err != nil || got != WarnLevel {
+		t.Errorf("ParseLevel(%q) = %v, %v, want %v", " WARNING ", got, err, WarnLevel)
+	}
+	if _, err := ParseLevel("loud"); err == nil {
+		t.Error("ParseLevel(\"loud\") expected error")
+	}
+}
@@ -3,11 +3,19 @@ package maleo
 import "strings"
 
 // Service represents the service information.
+//
+// Use DetectService to fill the fields from the build information and the environment variables.
 type Service struct {
 	Name        string `json:"name,omitempty"`
 	Environment string `json:"environment,omitempty"`
 	Type        string `json:"type,omitempty"`
 	Version     string `json:"version,omitempty"`
+	// Instance identifies the running instance of the service, e.g. the pod name or the hostname.
+	Instance string `json:"instance,omitempty"`
+	// Region is the region or zone where the instance runs.
+	Region string `json:"region,omitempty"`
+	// Commit is the version control revision the service is built from.
+	Commit string `json:"commit,omitempty"`
 }
 
 // String returns the string representation of the service information.
@@ -16,7 +24,7 @@ type Service struct {
 func (s Service) String() string {
 	written := false
 	builder := strings.Builder{}
-	builder.Grow(len(s.Name) + len(s.Environment) + len(s.Type) + 2)
+	builder.Grow(len(s.Name) + len(s.Version) + len(s.Environment) + len(s.Type) + 3)
 	if s.Name != "" {
 		builder.WriteString(s.Name)
 		written = true
@@ -27,7 +35,7 @@ func (s Service) String() string {
 			builder.WriteRune('-')
 		}
 		written = true
-		builder.WriteString(s.Environment)
+		builder.WriteString(s.Version)
 	}
 
 	if s.Type != "" {
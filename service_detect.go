@@ -0,0 +1,112 @@
+package maleo
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var majorVersionSuffix = regexp.MustCompile(`^v[0-9]+$`)
+
+/*
+DetectService creates a Service from the build information and the environment variables. Values set by the options
+take precedence over the detected ones.
+
+  - Name is the last element of the main module path, e.g. "billing" for "github.com/acme/billing/v2", or the name of
+    the executable if the module path is not available;
+  - Version is the version of the main module, or the short VCS revision with a "-dirty" suffix for uncommitted
+    changes if the binary is built from a working tree;
+  - Commit is the full VCS revision;
+  - Environment, Instance and Region are read from the first non-empty environment variable in
+    DetectServiceParameters. Instance falls back to the hostname.
+
+Example:
+
+	mal := maleo.New(maleo.DetectService(maleo.Option.DetectService().Type("api")))
+*/
+func DetectService(opts ...DetectServiceOption) Service {
+	params := defaultDetectServiceParameters()
+	for _, opt := range opts {
+		opt.Apply(&params)
+	}
+	detected := detectBuildService()
+	detected.Environment = firstEnv(params.EnvironmentVars)
+	detected.Instance = firstEnv(params.InstanceVars)
+	if detected.Instance == "" {
+		detected.Instance, _ = os.Hostname()
+	}
+	detected.Region = firstEnv(params.RegionVars)
+
+	s := params.Service
+	s.Name = firstNonEmpty(s.Name, detected.Name)
+	s.Version = firstNonEmpty(s.Version, detected.Version)
+	s.Commit = firstNonEmpty(s.Commit, detected.Commit)
+	s.Environment = firstNonEmpty(s.Environment, detected.Environment)
+	s.Instance = firstNonEmpty(s.Instance, detected.Instance)
+	s.Region = firstNonEmpty(s.Region, detected.Region)
+	return s
+}
+
+// detectBuildService fills Name, Version and Commit from debug.ReadBuildInfo.
+func detectBuildService() Service {
+	var s Service
+	if info, ok := readBuildInfo(); ok {
+		s.Name = moduleName(info.Main.Path)
+		if v := info.Main.Version; v != "" && v != "(devel)" {
+			s.Version = v
+		}
+		var modified bool
+		for _, setting := range info.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				s.Commit = setting.Value
+			case "vcs.modified":
+				modified = setting.Value == "true"
+			}
+		}
+		if s.Version == "" && s.Commit != "" {
+			s.Version = s.Commit
+			if len(s.Version) > 12 {
+				s.Version = s.Version[:12]
+			}
+			if modified {
+				s.Version += "-dirty"
+			}
+		}
+	}
+	if s.Name == "" && len(os.Args) > 0 {
+		s.Name = strings.TrimSuffix(filepath.Base(os.Args[0]), ".exe")
+	}
+	return s
+}
+
+func moduleName(modulePath string) string {
+	if modulePath == "" {
+		return ""
+	}
+	name := path.Base(modulePath)
+	if majorVersionSuffix.MatchString(name) {
+		name = path.Base(path.Dir(modulePath))
+	}
+	return name
+}
+
+func firstEnv(vars []string) string {
+	for _, v := range vars {
+		if value := os.Getenv(v); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,112 @@
+package maleo
+
+type DetectServiceParameters struct {
+	// Service holds the values that take precedence over the detected ones.
+	Service Service
+	// EnvironmentVars are the environment variables checked in order for the Environment.
+	// Defaults to APP_ENV, ENVIRONMENT, ENV and POD_NAMESPACE.
+	EnvironmentVars []string
+	// InstanceVars are the environment variables checked in order for the Instance, before falling back to the
+	// hostname. Defaults to POD_NAME and HOSTNAME.
+	InstanceVars []string
+	// RegionVars are the environment variables checked in order for the Region.
+	// Defaults to REGION, AWS_REGION, AWS_DEFAULT_REGION, GOOGLE_CLOUD_REGION and FLY_REGION.
+	RegionVars []string
+}
+
+func defaultDetectServiceParameters() DetectServiceParameters {
+	return DetectServiceParameters{
+		EnvironmentVars: []string{"APP_ENV", "ENVIRONMENT", "ENV", "POD_NAMESPACE"},
+		InstanceVars:    []string{"POD_NAME", "HOSTNAME"},
+		RegionVars:      []string{"REGION", "AWS_REGION", "AWS_DEFAULT_REGION", "GOOGLE_CLOUD_REGION", "FLY_REGION"},
+	}
+}
+
+type DetectServiceOption interface {
+	Apply(*DetectServiceParameters)
+}
+
+type (
+	DetectServiceOptionBuilder []DetectServiceOption
+	DetectServiceOptionFunc    func(*DetectServiceParameters)
+)
+
+func (d DetectServiceOptionFunc) Apply(parameters *DetectServiceParameters) {
+	d(parameters)
+}
+
+func (d DetectServiceOptionBuilder) Apply(parameters *DetectServiceParameters) {
+	for _, opt := range d {
+		opt.Apply(parameters)
+	}
+}
+
+// Name sets the name of the service instead of detecting it.
+func (d DetectServiceOptionBuilder) Name(name string) DetectServiceOptionBuilder {
+	return append(d, DetectServiceOptionFunc(func(p *DetectServiceParameters) {
+		p.Service.Name = name
+	}))
+}
+
+// Type sets the type of the service, e.g. "api" or "worker". The type is never detected.
+func (d DetectServiceOptionBuilder) Type(t string) DetectServiceOptionBuilder {
+	return append(d, DetectServiceOptionFunc(func(p *DetectServiceParameters) {
+		p.Service.Type = t
+	}))
+}
+
+// Version sets the version of the service instead of detecting it.
+func (d DetectServiceOptionBuilder) Version(version string) DetectServiceOptionBuilder {
+	return append(d, DetectServiceOptionFunc(func(p *DetectServiceParameters) {
+		p.Service.Version = version
+	}))
+}
+
+// Environment sets the environment of the service instead of detecting it.
+func (d DetectServiceOptionBuilder) Environment(env string) DetectServiceOptionBuilder {
+	return append(d, DetectServiceOptionFunc(func(p *DetectServiceParameters) {
+		p.Service.Environment = env
+	}))
+}
+
+// Instance sets the instance of the service instead of detecting it.
+func (d DetectServiceOptionBuilder) Instance(instance string) DetectServiceOptionBuilder {
+	return append(d, DetectServiceOptionFunc(func(p *DetectServiceParameters) {
+		p.Service.Instance = instance
+	}))
+}
+
+// Region sets the region of the service instead of detecting it.
+func (d DetectServiceOptionBuilder) Region(region string) DetectServiceOptionBuilder {
+	return append(d, DetectServiceOptionFunc(func(p *DetectServiceParameters) {
+		p.Service.Region = region
+	}))
+}
+
+// Commit sets the version control revision of the service instead of detecting it.
+func (d DetectServiceOptionBuilder) Commit(commit string) DetectServiceOptionBuilder {
+	return append(d, DetectServiceOptionFunc(func(p *DetectServiceParameters) {
+		p.Service.Commit = commit
+	}))
+}
+
+// EnvironmentVars replaces the environment variables checked for the Environment.
+func (d DetectServiceOptionBuilder) EnvironmentVars(vars ...string) DetectServiceOptionBuilder {
+	return append(d, DetectServiceOptionFunc(func(p *DetectServiceParameters) {
+		p.EnvironmentVars = vars
+	}))
+}
+
+// InstanceVars replaces the environment variables checked for the Instance.
+func (d DetectServiceOptionBuilder) InstanceVars(vars ...string) DetectServiceOptionBuilder {
+	return append(d, DetectServiceOptionFunc(func(p *DetectServiceParameters) {
+		p.InstanceVars = vars
+	}))
+}
+
+// RegionVars replaces the environment variables checked for the Region.
+func (d DetectServiceOptionBuilder) RegionVars(vars ...string) DetectServiceOptionBuilder {
+	return append(d, DetectServiceOptionFunc(func(p *DetectServiceParameters) {
+		p.RegionVars = vars
+	}))
+}
@@ -0,0 +1,69 @@
+package maleo
+
+import (
+	"runtime/debug"
+	"testing"
+)
+
+func TestService_String(t *testing.T) {
+	tests := []struct {
+		name    string
+		service Service
+		want    string
+	}{
+		{name: "empty", service: Service{}, want: ""},
+		{name: "name only", service: Service{Name: "api"}, want: "api"},
+		{
+			name:    "all fields",
+			service: Service{Name: "api", Version: "v1.2.0", Type: "http", Environment: "prod", Region: "eu-west-1"},
+			want:    "api-v1.2.0-http-prod",
+		},
+		{name: "version without name", service: Service{Version: "v1.2.0", Environment: "prod"}, want: "v1.2.0-prod"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.service.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectService(t *testing.T) {
+	defer func(f func() (*debug.BuildInfo, bool)) { readBuildInfo = f }(readBuildInfo)
+	readBuildInfo = func() (*debug.BuildInfo, bool) {
+		return &debug.BuildInfo{
+			Main: debug.Module{Path: "github.com/acme/billing/v2", Version: "(devel)"},
+			Settings: []debug.BuildSetting{
+				{Key: "vcs.revision", Value: "0123456789abcdef0123"},
+				{Key: "vcs.modified", Value: "true"},
+			},
+		}, true
+	}
+	t.Setenv("APP_ENV", "")
+	t.Setenv("ENVIRONMENT", "")
+	t.Setenv("ENV", "staging")
+	t.Setenv("POD_NAMESPACE", "billing-prod")
+	t.Setenv("POD_NAME", "billing-7d9f")
+	t.Setenv("REGION", "")
+	t.Setenv("AWS_REGION", "ap-southeast-1")
+
+	got := DetectService(Option.DetectService().Type("worker"))
+	want := Service{
+		Name:        "billing",
+		Environment: "staging",
+		Type:        "worker",
+		Version:     "0123456789ab-dirty",
+		Instance:    "billing-7d9f",
+		Region:      "ap-southeast-1",
+		Commit:      "0123456789abcdef0123",
+	}
+	if got != want {
+		t.Errorf("DetectService() = %+v, want %+v", got, want)
+	}
+
+	got = DetectService(Option.DetectService().Name("ledger").Version("v2.1.0").EnvironmentVars("POD_NAMESPACE"))
+	if got.Name != "ledger" || got.Version != "v2.1.0" || got.Environment != "billing-prod" {
+		t.Errorf("DetectService() = %+v, want the values of the options", got)
+	}
+}
@@ -0,0 +1,200 @@
+//go:build go1.21
+
+package maleo
+
+import (
+	"encoding/json"
+	"log/slog"
+	"sort"
+	"time"
+)
+
+var (
+	_ slog.LogValuer = (Fields)(nil)
+	_ slog.LogValuer = EntryNode{}
+	_ slog.LogValuer = (*ErrorNode)(nil)
+	_ slog.LogValuer = contextFieldsError{}
+	_ slog.LogValuer = (*caller)(nil)
+	_ slog.LogValuer = Service{}
+)
+
+// LogValue implements slog.LogValuer. Fields are rendered as a group with sorted keys.
+//
+// Nested Fields and map[string]any values are rendered as nested groups.
+func (f Fields) LogValue() slog.Value {
+	keys := make([]string, 0, len(f))
+	for k := range f {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	attrs := make([]slog.Attr, 0, len(keys))
+	for _, k := range keys {
+		attrs = append(attrs, slog.Attr{Key: k, Value: logValueOf(f[k])})
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// LogValue implements slog.LogValuer.
+func (c caller) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("file", c.String()),
+		slog.String("function", c.ShortName()),
+	)
+}
+
+// LogValue implements slog.LogValuer. Empty fields are omitted.
+func (s Service) LogValue() slog.Value {
+	attrs := make([]slog.Attr, 0, 7)
+	if s.Name != "" {
+		attrs = append(attrs, slog.String("name", s.Name))
+	}
+	if s.Environment != "" {
+		attrs = append(attrs, slog.String("environment", s.Environment))
+	}
+	if s.Type != "" {
+		attrs = append(attrs, slog.String("type", s.Type))
+	}
+	if s.Version != "" {
+		attrs = append(attrs, slog.String("version", s.Version))
+	}
+	if s.Instance != "" {
+		attrs = append(attrs, slog.String("instance", s.Instance))
+	}
+	if s.Region != "" {
+		attrs = append(attrs, slog.String("region", s.Region))
+	}
+	if s.Commit != "" {
+		attrs = append(attrs, slog.String("commit", s.Commit))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// LogValue implements slog.LogValuer. The entry is rendered as a group, and the Context is rendered as nested group.
+func (e EntryNode) LogValue() slog.Value {
+	attrs := make([]slog.Attr, 0, 10)
+	attrs = append(attrs,
+		slog.String("time", e.Time().Format(time.RFC3339)),
+		slog.String("level", e.Level().String()),
+	)
+	if code := e.Code(); code != 0 {
+		attrs = append(attrs, slog.Int("code", code))
+	}
+	attrs = append(attrs, slog.String("message", e.Message()))
+	if e.Caller() != nil {
+		attrs = append(attrs, slog.Any("caller", e.Caller()))
+	}
+	if key := e.Key(); key != "" {
+		attrs = append(attrs, slog.String("key", key))
+	}
+	attrs = append(attrs, slog.Any("service", e.Service()))
+	if component := e.Component(); component != "" {
+		attrs = append(attrs, slog.String("component", component))
+	}
+	if fields := e.ContextFields(); len(fields) > 0 {
+		attrs = append(attrs, slog.Any("context_fields", fields))
+	}
+	if ctx := e.Context(); len(ctx) > 0 {
+		attrs = append(attrs, slog.Attr{Key: "context", Value: ContextLogValue(ctx)})
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// LogValue implements slog.LogValuer. The error is rendered as a group, the Context is rendered as nested group,
+// and the wrapped error is rendered under the "error" key.
+func (e *ErrorNode) LogValue() slog.Value {
+	if e == nil {
+		return slog.AnyValue(nil)
+	}
+	return e.logValue(e.ContextFields())
+}
+
+// LogValue implements slog.LogValuer. The error is rendered like the wrapped ErrorNode, with the request scoped fields.
+func (e contextFieldsError) LogValue() slog.Value {
+	return e.ErrorNode.logValue(e.ContextFields())
+}
+
+func (e *ErrorNode) logValue(contextFields Fields) slog.Value {
+	attrs := make([]slog.Attr, 0, 12)
+	attrs = append(attrs,
+		slog.String("time", e.Time().Format(time.RFC3339)),
+		slog.Int("code", e.Code()),
+		slog.String("message", e.Message()),
+	)
+	if e.Caller() != nil {
+		attrs = append(attrs, slog.Any("caller", e.Caller()))
+	}
+	if frames := e.Frames(); len(frames) > 0 {
+		attrs = append(attrs, slog.Any("stack", frames))
+	}
+	if key := e.Key(); key != "" {
+		attrs = append(attrs, slog.String("key", key))
+	}
+	attrs = append(attrs,
+		slog.String("level", e.Level().String()),
+		slog.Any("service", e.Service()),
+	)
+	if component := e.Component(); component != "" {
+		attrs = append(attrs, slog.String("component", component))
+	}
+	if len(contextFields) > 0 {
+		attrs = append(attrs, slog.Any("context_fields", contextFields))
+	}
+	if ctx := e.Context(); len(ctx) > 0 {
+		attrs = append(attrs, slog.Attr{Key: "context", Value: ContextLogValue(ctx)})
+	}
+	attrs = append(attrs, slog.Attr{Key: "error", Value: ErrorLogValue(e.Unwrap())})
+	return slog.GroupValue(attrs...)
+}
+
+// ContextLogValue turns the Context values of Entry or Error into slog.Value.
+//
+// A single value is rendered as is. Multiple values are paired by ContextMap and are rendered as a group with sorted
+// keys.
+func ContextLogValue(ctx []any) slog.Value {
+	switch len(ctx) {
+	case 0:
+		return slog.AnyValue(nil)
+	case 1:
+		return logValueOf(ctx[0])
+	}
+	return Fields(ContextMap(ctx)).LogValue()
+}
+
+// ErrorLogValue turns an error into slog.Value.
+//
+// If the error implements slog.LogValuer, the error's own implementation is used. Otherwise, the error is rendered as
+// a group with "summary" key containing err.Error() and "details" key containing the json representation of the error,
+// if the error does not marshal into empty json value.
+func ErrorLogValue(err error) slog.Value {
+	if err == nil {
+		return slog.AnyValue(nil)
+	}
+	if lv, ok := err.(slog.LogValuer); ok { //nolint:errorlint
+		return slog.AnyValue(lv)
+	}
+	summary := slog.String("summary", err.Error())
+	b, errMarshal := json.Marshal(err)
+	if errMarshal != nil {
+		return slog.GroupValue(summary)
+	}
+	switch {
+	case len(b) == 2 && b[0] == '{' && b[1] == '}',
+		len(b) == 2 && b[0] == '[' && b[1] == ']',
+		len(b) == 2 && b[0] == '"' && b[1] == '"':
+		return slog.GroupValue(summary)
+	}
+	return slog.GroupValue(summary, slog.Any("details", json.RawMessage(b)))
+}
+
+func logValueOf(v any) slog.Value {
+	switch v := v.(type) {
+	case slog.LogValuer:
+		return slog.AnyValue(v)
+	case map[string]any:
+		return Fields(v).LogValue()
+	case error:
+		return ErrorLogValue(v)
+	default:
+		return slog.AnyValue(v)
+	}
+}
@@ -0,0 +1,102 @@
+//go:build go1.21
+
+package maleo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+)
+
+func TestLogValuer(t *testing.T) {
+	m, _ := NewTestingMaleo()
+	buf := new(bytes.Buffer)
+	logger := slog.New(slog.NewJSONHandler(buf, nil))
+
+	entry := m.NewEntry("entry").Context(F{"foo": "bar", "nested": F{"baz": 1}}).Freeze()
+	inner := m.Bail("inner").Code(400).Context("user_id", 5, "extra", map[string]any{"a": "b"}).Freeze()
+	err := m.Wrap(inner, "outer").Freeze()
+	logger.LogAttrs(context.Background(), slog.LevelInfo, "test",
+		slog.Any("entry", entry),
+		slog.Any("err", err),
+		slog.Any("plain", errors.New("plain")),
+		slog.Any("fields", F{"x": 1}),
+	)
+
+	var got struct {
+		Entry struct {
+			Message string `json:"message"`
+			Context struct {
+				Foo    string `json:"foo"`
+				Nested struct {
+					Baz int `json:"baz"`
+				} `json:"nested"`
+			} `json:"context"`
+			Service Service `json:"service"`
+		} `json:"entry"`
+		Err struct {
+			Message string `json:"message"`
+			Error   struct {
+				Message string `json:"message"`
+				Code    int    `json:"code"`
+				Context struct {
+					UserID int               `json:"user_id"`
+					Extra  map[string]string `json:"extra"`
+				} `json:"context"`
+				Error struct {
+					Summary string `json:"summary"`
+				} `json:"error"`
+			} `json:"error"`
+		} `json:"err"`
+		Plain  string         `json:"plain"`
+		Fields map[string]int `json:"fields"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("failed to unmarshal: %v: %s", err, buf.String())
+	}
+	if got.Entry.Message != "entry" {
+		t.Errorf("entry.message = %v, want %v", got.Entry.Message, "entry")
+	}
+	if got.Entry.Context.Foo != "bar" || got.Entry.Context.Nested.Baz != 1 {
+		t.Errorf("entry.context = %+v, want foo=bar and nested.baz=1", got.Entry.Context)
+	}
+	if got.Entry.Service != m.Service() {
+		t.Errorf("entry.service = %+v, want %+v", got.Entry.Service, m.Service())
+	}
+	if got.Err.Message != "outer" {
+		t.Errorf("err.message = %v, want %v", got.Err.Message, "outer")
+	}
+	if got.Err.Error.Message != "inner" || got.Err.Error.Code != 400 {
+		t.Errorf("err.error = %+v, want message=inner and code=400", got.Err.Error)
+	}
+	if got.Err.Error.Context.UserID != 5 || got.Err.Error.Context.Extra["a"] != "b" {
+		t.Errorf("err.error.context = %+v, want user_id=5 and extra.a=b", got.Err.Error.Context)
+	}
+	if got.Err.Error.Error.Summary != "inner" {
+		t.Errorf("err.error.error.summary = %v, want %v", got.Err.Error.Error.Summary, "inner")
+	}
+	if got.Plain != "plain" {
+		t.Errorf("plain = %v, want %v", got.Plain, "plain")
+	}
+	if got.Fields["x"] != 1 {
+		t.Errorf("fields.x = %v, want %v", got.Fields["x"], 1)
+	}
+}
+
+func TestCallerFromPC(t *testing.T) {
+	c := GetCaller(1)
+	got := CallerFromPC(c.PC())
+	if got.File() != c.File() || got.Line() != c.Line() {
+		t.Errorf("CallerFromPC() = %v, want %v", got, c)
+	}
+	if got.ShortName() != "maleo.TestCallerFromPC" {
+		t.Errorf("CallerFromPC().ShortName() = %v, want %v", got.ShortName(), "maleo.TestCallerFromPC")
+	}
+	empty := CallerFromPC(0)
+	if empty.Name() != "" || empty.Line() != 0 {
+		t.Errorf("CallerFromPC(0) = %v, want empty caller", empty)
+	}
+}
@@ -0,0 +1,5 @@
+module github.com/tigorlazuardi/maleo/spool
+
+go 1.19
+
+require github.com/tigorlazuardi/maleo v0.5.0
@@ -0,0 +1,46 @@
+package spool
+
+import "time"
+
+type Option interface {
+	apply(*Spool)
+}
+
+type OptionFunc func(*Spool)
+
+func (o OptionFunc) apply(s *Spool) {
+	o(s)
+}
+
+// WithMaxBytes sets the size cap of the Spool. When the Spool would grow past the cap, the oldest segments are removed,
+// including their pending messages. Defaults to 64 MiB.
+func WithMaxBytes(n int64) Option {
+	return OptionFunc(func(s *Spool) {
+		s.maxBytes = n
+	})
+}
+
+// WithSegmentSize sets the size of a segment file before a new one is started. Defaults to 4 MiB.
+//
+// Append rejects messages larger than the segment size plus 1 MiB with ErrTooLarge.
+func WithSegmentSize(n int64) Option {
+	return OptionFunc(func(s *Spool) {
+		s.segmentSize = n
+	})
+}
+
+// WithRetention sets how long pending messages are kept. Set to 0 to keep them until they are done or removed by the
+// size cap. Defaults to 7 days.
+func WithRetention(d time.Duration) Option {
+	return OptionFunc(func(s *Spool) {
+		s.retention = d
+	})
+}
+
+// WithSync sets whether every write is flushed to the disk with fsync before Append and Done return.
+// Disabling it is faster, but messages may be lost on power failure. Defaults to true.
+func WithSync(b bool) Option {
+	return OptionFunc(func(s *Spool) {
+		s.sync = b
+	})
+}
@@ -0,0 +1,210 @@
+package spool
+
+import (
+	"encoding/json"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/tigorlazuardi/maleo"
+)
+
+// Record is the serialised form of a maleo.MessageContext.
+type Record struct {
+	ID            uint64          `json:"id"`
+	SpooledAt     time.Time       `json:"spooled_at"`
+	Time          time.Time       `json:"time"`
+	Message       string          `json:"message"`
+	Level         maleo.Level     `json:"level"`
+	Code          int             `json:"code"`
+	HTTPCode      int             `json:"http_code"`
+	Key           string          `json:"key,omitempty"`
+	Component     string          `json:"component,omitempty"`
+	Service       maleo.Service   `json:"service"`
+	Caller        *Caller         `json:"caller,omitempty"`
+	Context       json.RawMessage `json:"context,omitempty"`
+	ContextFields maleo.Fields    `json:"context_fields,omitempty"`
+	Error         *Error          `json:"error,omitempty"`
+	ForceSend     bool            `json:"force_send,omitempty"`
+	Cooldown      time.Duration   `json:"cooldown,omitempty"`
+}
+
+// NewRecord serialises the message. The error chain and the context are kept as their JSON representation.
+func NewRecord(msg maleo.MessageContext) *Record {
+	r := &Record{
+		Time:      msg.Time(),
+		Message:   msg.Message(),
+		Level:     msg.Level(),
+		Code:      msg.Code(),
+		HTTPCode:  msg.HTTPCode(),
+		Key:       msg.Key(),
+		Service:   msg.Service(),
+		ForceSend: msg.ForceSend(),
+		Cooldown:  msg.Cooldown(),
+	}
+	if c := msg.Caller(); c != nil {
+		r.Caller = &Caller{FunctionName: c.Name(), FilePath: c.File(), LineNumber: c.Line()}
+	}
+	if ch, ok := msg.(maleo.ComponentHint); ok {
+		r.Component = ch.Component()
+	}
+	if cf, ok := msg.(maleo.ContextFieldsHint); ok {
+		r.ContextFields = cf.ContextFields()
+	}
+	switch data := msg.Context(); len(data) {
+	case 0:
+	case 1:
+		r.Context = marshalOrString(data[0])
+	default:
+		r.Context = marshalOrString(data)
+	}
+	if err := msg.Err(); err != nil {
+		r.Error = &Error{Text: err.Error(), Chain: marshalOrString(err)}
+	}
+	return r
+}
+
+func marshalOrString(v any) json.RawMessage {
+	b, err := json.Marshal(v)
+	if err != nil {
+		b, _ = json.Marshal(err.Error())
+	}
+	return b
+}
+
+// MessageContext restores the message. m becomes the Maleo instance of the message, and is used by Messengers to
+// log their own errors. If m is nil, the Global instance at the time the message is sent is used.
+func (r *Record) MessageContext(m *maleo.Maleo) maleo.MessageContext {
+	return &messageContext{record: r, maleo: m}
+}
+
+var (
+	_ maleo.MessageContext    = (*messageContext)(nil)
+	_ maleo.ComponentHint     = (*messageContext)(nil)
+	_ maleo.ContextFieldsHint = (*messageContext)(nil)
+)
+
+type messageContext struct {
+	record *Record
+	maleo  *maleo.Maleo
+}
+
+func (m *messageContext) HTTPCode() int      { return m.record.HTTPCode }
+func (m *messageContext) Code() int          { return m.record.Code }
+func (m *messageContext) Message() string    { return m.record.Message }
+func (m *messageContext) Key() string        { return m.record.Key }
+func (m *messageContext) Level() maleo.Level { return m.record.Level }
+func (m *messageContext) Service() maleo.Service {
+	return m.record.Service
+}
+func (m *messageContext) Time() time.Time             { return m.record.Time }
+func (m *messageContext) ForceSend() bool             { return m.record.ForceSend }
+func (m *messageContext) Cooldown() time.Duration     { return m.record.Cooldown }
+func (m *messageContext) Component() string           { return m.record.Component }
+func (m *messageContext) ContextFields() maleo.Fields { return m.record.ContextFields }
+
+func (m *messageContext) Maleo() *maleo.Maleo {
+	if m.maleo == nil {
+		return maleo.Global()
+	}
+	return m.maleo
+}
+
+func (m *messageContext) Caller() maleo.Caller {
+	if m.record.Caller == nil {
+		return &Caller{}
+	}
+	return m.record.Caller
+}
+
+// Context returns the context of the message as a single json.RawMessage.
+func (m *messageContext) Context() []any {
+	if len(m.record.Context) == 0 {
+		return nil
+	}
+	return []any{m.record.Context}
+}
+
+// Err returns the restored error. The error marshals to the JSON representation of the original error.
+func (m *messageContext) Err() error {
+	if m.record.Error == nil {
+		return nil
+	}
+	return restoredError{m.record.Error}
+}
+
+// Error is the serialised form of the error of a message.
+type Error struct {
+	// Text is the result of Error() of the original error.
+	Text string `json:"text"`
+	// Chain is the JSON representation of the original error.
+	Chain json.RawMessage `json:"chain,omitempty"`
+}
+
+type restoredError struct {
+	err *Error
+}
+
+func (e restoredError) Error() string {
+	return e.err.Text
+}
+
+func (e restoredError) MarshalJSON() ([]byte, error) {
+	if len(e.err.Chain) == 0 {
+		return json.Marshal(e.err.Text)
+	}
+	return e.err.Chain, nil
+}
+
+var _ maleo.Caller = (*Caller)(nil)
+
+// Caller is the restored caller of a Record. Program counter is not available for restored callers,
+// so Function returns nil and PC returns 0.
+type Caller struct {
+	FunctionName string `json:"function"`
+	FilePath     string `json:"file"`
+	LineNumber   int    `json:"line"`
+}
+
+func (c *Caller) Function() *runtime.Func { return nil }
+func (c *Caller) Name() string            { return c.FunctionName }
+func (c *Caller) Line() int               { return c.LineNumber }
+func (c *Caller) File() string            { return c.FilePath }
+func (c *Caller) PC() uintptr             { return 0 }
+func (c *Caller) Depth() int              { return 0 }
+
+func (c *Caller) ShortName() string {
+	s := strings.Split(c.FunctionName, "/")
+	return s[len(s)-1]
+}
+
+func (c *Caller) ShortSource() string {
+	sep := string(os.PathSeparator)
+	s := strings.Split(c.FilePath, sep)
+	for len(s) > 3 {
+		s = s[1:]
+	}
+	return strings.Join(s, sep)
+}
+
+func (c *Caller) String() string {
+	return c.FilePath + ":" + strconv.Itoa(c.LineNumber)
+}
+
+func (c *Caller) FormatAsKey() string {
+	s := &strings.Builder{}
+	for _, r := range c.FilePath {
+		switch {
+		case unicode.In(r, unicode.Digit, unicode.Letter), r == '-', r == '.':
+			s.WriteRune(r)
+		default:
+			s.WriteRune('_')
+		}
+	}
+	s.WriteRune('_')
+	s.WriteString(strconv.Itoa(c.LineNumber))
+	return s.String()
+}
@@ -0,0 +1,416 @@
+// Package spool is a write-ahead log on the local disk for maleo messages, so Messengers can keep the messages
+// that are not delivered yet across restarts and crashes.
+package spool
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tigorlazuardi/maleo"
+)
+
+const segmentExt = ".wal"
+
+var (
+	// ErrClosed is returned by the operations on a closed Spool.
+	ErrClosed = errors.New("spool: closed")
+	// ErrTooLarge is returned by Append for messages that are larger than the record size limit of the Spool.
+	ErrTooLarge = errors.New("spool: message too large")
+)
+
+/*
+Spool is a write-ahead log of messages in a local directory.
+
+A Messenger appends a message to the Spool before it tries to deliver it, and marks it done after it is delivered.
+Messages that are not marked done, e.g. because the process stopped, are returned by Pending when the Spool is opened
+again.
+
+The log is split into segment files. A segment is removed once every message in it is done or expired, or when the
+Spool grows past the size cap, starting from the oldest segment.
+*/
+type Spool struct {
+	dir         string
+	maxBytes    int64
+	segmentSize int64
+	retention   time.Duration
+	sync        bool
+
+	mu       sync.Mutex
+	closed   bool
+	lastID   uint64
+	segments []*segment
+	current  *os.File
+	pending  map[uint64]*pendingRecord
+	dropped  int
+	skipped  int
+}
+
+type segment struct {
+	seq     uint64
+	size    int64
+	pending int
+}
+
+type pendingRecord struct {
+	record  *Record
+	segment *segment
+}
+
+type entry struct {
+	Op     string  `json:"op"`
+	ID     uint64  `json:"id,omitempty"`
+	Record *Record `json:"record,omitempty"`
+}
+
+// Open opens the Spool in dir, creating dir if it does not exist, and replays the existing segments.
+func Open(dir string, opts ...Option) (*Spool, error) {
+	s := &Spool{
+		dir:         dir,
+		maxBytes:    64 * 1024 * 1024,
+		segmentSize: 4 * 1024 * 1024,
+		retention:   7 * 24 * time.Hour,
+		sync:        true,
+		pending:     map[uint64]*pendingRecord{},
+	}
+	for _, opt := range opts {
+		opt.apply(s)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("spool: failed to create directory: %w", err)
+	}
+	if err := s.replay(); err != nil {
+		return nil, err
+	}
+	s.expire(time.Now())
+	if err := s.rotate(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Spool) replay() error {
+	files, err := filepath.Glob(filepath.Join(s.dir, "*"+segmentExt))
+	if err != nil {
+		return fmt.Errorf("spool: failed to list segments: %w", err)
+	}
+	for _, file := range files {
+		seq, err := strconv.ParseUint(strings.TrimSuffix(filepath.Base(file), segmentExt), 10, 64)
+		if err != nil {
+			continue
+		}
+		s.segments = append(s.segments, &segment{seq: seq})
+	}
+	sort.Slice(s.segments, func(i, j int) bool { return s.segments[i].seq < s.segments[j].seq })
+	for _, seg := range s.segments {
+		if err := s.replaySegment(seg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Spool) replaySegment(seg *segment) error {
+	f, err := os.Open(s.segmentPath(seg.seq))
+	if err != nil {
+		return fmt.Errorf("spool: failed to open segment: %w", err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("spool: failed to stat segment: %w", err)
+	}
+	seg.size = info.Size()
+	reader := bufio.NewReaderSize(f, 64*1024)
+	limit := s.maxRecordSize()
+	var (
+		line      []byte
+		oversized bool
+	)
+	for {
+		chunk, err := reader.ReadSlice('\n')
+		if !oversized {
+			line = append(line, chunk...)
+			// Records over the limit are skipped without holding them in memory.
+			if int64(len(line)) > limit {
+				oversized = true
+				line = line[:0]
+			}
+		}
+		if errors.Is(err, bufio.ErrBufferFull) {
+			continue
+		}
+		if oversized {
+			s.skipped++
+		} else if len(bytes.TrimSpace(line)) > 0 {
+			s.replayLine(seg, line)
+		}
+		line, oversized = line[:0], false
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("spool: failed to read segment: %w", err)
+		}
+	}
+}
+
+// replayLine applies one line of a segment. Lines that cannot be decoded, e.g. a partially written line left at the
+// end of the segment by a crash, are skipped.
+func (s *Spool) replayLine(seg *segment, line []byte) {
+	var e entry
+	if err := json.Unmarshal(line, &e); err != nil {
+		s.skipped++
+		return
+	}
+	switch e.Op {
+	case "put":
+		if e.Record == nil {
+			s.skipped++
+			return
+		}
+		s.pending[e.Record.ID] = &pendingRecord{record: e.Record, segment: seg}
+		seg.pending++
+		if e.Record.ID > s.lastID {
+			s.lastID = e.Record.ID
+		}
+	case "done":
+		if p, ok := s.pending[e.ID]; ok {
+			p.segment.pending--
+			delete(s.pending, e.ID)
+		}
+	}
+}
+
+// maxRecordSize is the size limit of a line in a segment.
+func (s *Spool) maxRecordSize() int64 {
+	return s.segmentSize + 1024*1024
+}
+
+// Append writes the message to the Spool. Returns the id to mark the message done with.
+func (s *Spool) Append(msg maleo.MessageContext) (uint64, error) {
+	record := NewRecord(msg)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return 0, ErrClosed
+	}
+	s.lastID++
+	record.ID = s.lastID
+	record.SpooledAt = time.Now()
+	b, err := json.Marshal(entry{Op: "put", Record: record})
+	if err != nil {
+		return 0, fmt.Errorf("spool: failed to serialise message: %w", err)
+	}
+	b = append(b, '\n')
+	if int64(len(b)) > s.maxRecordSize() {
+		return 0, ErrTooLarge
+	}
+	if err := s.ensureCapacity(int64(len(b))); err != nil {
+		return 0, err
+	}
+	seg := s.segments[len(s.segments)-1]
+	if err := s.write(b); err != nil {
+		return 0, err
+	}
+	seg.pending++
+	s.pending[record.ID] = &pendingRecord{record: record, segment: seg}
+	return record.ID, nil
+}
+
+// Done marks the message with the given id as delivered, so it is not returned by Pending anymore.
+// Marking unknown or already done ids is a no-op.
+func (s *Spool) Done(id uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return ErrClosed
+	}
+	p, ok := s.pending[id]
+	if !ok {
+		return nil
+	}
+	b, _ := json.Marshal(entry{Op: "done", ID: id})
+	b = append(b, '\n')
+	if err := s.ensureCapacity(int64(len(b))); err != nil {
+		return err
+	}
+	if err := s.write(b); err != nil {
+		return err
+	}
+	delete(s.pending, id)
+	p.segment.pending--
+	s.compact()
+	return nil
+}
+
+// Pending returns the messages that are not done yet, oldest first. Messages older than the retention are left out.
+// Returns nil if the Spool is closed.
+func (s *Spool) Pending() []*Record {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.expire(time.Now())
+	records := make([]*Record, 0, len(s.pending))
+	for _, p := range s.pending {
+		records = append(records, p.record)
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].ID < records[j].ID })
+	return records
+}
+
+// Len returns the number of messages that are not done yet.
+func (s *Spool) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.pending)
+}
+
+// Size returns the total size of the segments in bytes.
+func (s *Spool) Size() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var size int64
+	for _, seg := range s.segments {
+		size += seg.size
+	}
+	return size
+}
+
+// Dropped returns the number of messages that are removed before they are done, because of the size cap or
+// the retention, since the Spool is opened.
+func (s *Spool) Dropped() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.dropped
+}
+
+// Skipped returns the number of lines in the segments that are skipped when the Spool is opened, because they cannot
+// be decoded or are larger than the record size limit. A partially written line left by a crash counts as one.
+func (s *Spool) Skipped() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.skipped
+}
+
+// Close closes the Spool. The messages that are not done are kept on disk.
+func (s *Spool) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	return s.current.Close()
+}
+
+func (s *Spool) segmentPath(seq uint64) string {
+	return filepath.Join(s.dir, fmt.Sprintf("%020d%s", seq, segmentExt))
+}
+
+func (s *Spool) write(b []byte) error {
+	if _, err := s.current.Write(b); err != nil {
+		return fmt.Errorf("spool: failed to write segment: %w", err)
+	}
+	if s.sync {
+		if err := s.current.Sync(); err != nil {
+			return fmt.Errorf("spool: failed to sync segment: %w", err)
+		}
+	}
+	s.segments[len(s.segments)-1].size += int64(len(b))
+	return nil
+}
+
+// ensureCapacity rotates the current segment if it is full, and removes the oldest segments if the Spool would grow
+// past the size cap.
+func (s *Spool) ensureCapacity(n int64) error {
+	if s.segments[len(s.segments)-1].size+n > s.segmentSize {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+	var total int64
+	for _, seg := range s.segments {
+		total += seg.size
+	}
+	for total+n > s.maxBytes && len(s.segments) > 1 {
+		oldest := s.segments[0]
+		total -= oldest.size
+		s.removeSegment(oldest)
+	}
+	return nil
+}
+
+func (s *Spool) rotate() error {
+	var seq uint64 = 1
+	if len(s.segments) > 0 {
+		seq = s.segments[len(s.segments)-1].seq + 1
+	}
+	f, err := os.OpenFile(s.segmentPath(seq), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("spool: failed to create segment: %w", err)
+	}
+	if s.current != nil {
+		_ = s.current.Close()
+	}
+	s.current = f
+	s.segments = append(s.segments, &segment{seq: seq})
+	s.compact()
+	return nil
+}
+
+// compact removes the oldest segments, other than the current one, as long as they have no pending messages.
+//
+// Only the oldest segments are removed, because the done marks of a segment's messages are written in the same or
+// newer segments. Removing a newer segment first would bring its done messages back on replay.
+func (s *Spool) compact() {
+	for len(s.segments) > 1 && s.segments[0].pending == 0 {
+		s.removeSegment(s.segments[0])
+	}
+}
+
+// expire drops the messages older than the retention, and removes the segments that are left without pending messages.
+func (s *Spool) expire(now time.Time) {
+	if s.retention <= 0 {
+		return
+	}
+	deadline := now.Add(-s.retention)
+	for id, p := range s.pending {
+		if p.record.SpooledAt.Before(deadline) {
+			delete(s.pending, id)
+			p.segment.pending--
+			s.dropped++
+		}
+	}
+	if len(s.segments) > 0 {
+		s.compact()
+	}
+}
+
+func (s *Spool) removeSegment(seg *segment) {
+	for id, p := range s.pending {
+		if p.segment == seg {
+			delete(s.pending, id)
+			s.dropped++
+		}
+	}
+	_ = os.Remove(s.segmentPath(seg.seq))
+	for i, v := range s.segments {
+		if v == seg {
+			s.segments = append(s.segments[:i], s.segments[i+1:]...)
+			break
+		}
+	}
+}
@@ -0,0 +1,266 @@
+package spool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tigorlazuardi/maleo"
+)
+
+type capturingMessenger struct {
+	messages []maleo.MessageContext
+}
+
+func (c *capturingMessenger) Name() string { return "capture" }
+func (c *capturingMessenger) SendMessage(_ context.Context, msg maleo.MessageContext) {
+	c.messages = append(c.messages, msg)
+}
+func (c *capturingMessenger) Wait(context.Context) error { return nil }
+
+// capture returns the MessageContext that Messengers receive when notify is called.
+func capture(t *testing.T, notify func(m *maleo.Maleo)) maleo.MessageContext {
+	t.Helper()
+	m, _ := maleo.NewTestingMaleo()
+	c := &capturingMessenger{}
+	m.Register(c)
+	notify(m)
+	if len(c.messages) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(c.messages))
+	}
+	return c.messages[0]
+}
+
+func testMessage(t *testing.T, message string) maleo.MessageContext {
+	t.Helper()
+	return capture(t, func(m *maleo.Maleo) {
+		m.NewEntry(message).Key("test." + message).Context(maleo.F{"message": message}).Notify(context.Background())
+	})
+}
+
+func segmentFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	files, err := filepath.Glob(filepath.Join(dir, "*"+segmentExt))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
+
+func TestSpool_Replay(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Open(dir)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	var ids []uint64
+	for _, message := range []string{"first", "second", "third"} {
+		id, err := s.Append(testMessage(t, message))
+		if err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := s.Done(ids[1]); err != nil {
+		t.Fatalf("Done() error = %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if _, err := s.Append(testMessage(t, "closed")); !errors.Is(err, ErrClosed) {
+		t.Errorf("Append() on closed Spool error = %v, want %v", err, ErrClosed)
+	}
+
+	s, err = Open(dir)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer s.Close()
+	pending := s.Pending()
+	if len(pending) != 2 || pending[0].Message != "first" || pending[1].Message != "third" {
+		t.Fatalf("Pending() after reopen returned %d records, want first and third", len(pending))
+	}
+	id, err := s.Append(testMessage(t, "fourth"))
+	if err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	if id <= ids[2] {
+		t.Errorf("Append() after reopen id = %d, want greater than %d", id, ids[2])
+	}
+	for _, r := range s.Pending() {
+		if err := s.Done(r.ID); err != nil {
+			t.Fatalf("Done() error = %v", err)
+		}
+	}
+	if s.Len() != 0 {
+		t.Errorf("Len() = %d, want 0", s.Len())
+	}
+	if files := segmentFiles(t, dir); len(files) != 1 {
+		t.Errorf("expected only the current segment to be left, got %v", files)
+	}
+}
+
+func TestSpool_PartialWrite(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Open(dir)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if _, err := s.Append(testMessage(t, "kept")); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	_ = s.Close()
+
+	files := segmentFiles(t, dir)
+	f, err := os.OpenFile(files[len(files)-1], os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString(`{"op":"put","record":{"id":99,"mess`)
+	_ = f.Close()
+
+	s, err = Open(dir)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer s.Close()
+	if pending := s.Pending(); len(pending) != 1 || pending[0].Message != "kept" {
+		t.Errorf("Pending() returned %d records, want only the kept record", len(pending))
+	}
+}
+
+func TestSpool_OversizedRecord(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Open(dir, WithSegmentSize(1024))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if _, err := s.Append(testMessage(t, strings.Repeat("x", 2*1024*1024))); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("Append() error = %v, want %v", err, ErrTooLarge)
+	}
+	if _, err := s.Append(testMessage(t, "before")); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	_ = s.Close()
+
+	// Lines written by older versions or damaged on disk must not keep the Spool from opening.
+	files := segmentFiles(t, dir)
+	f, err := os.OpenFile(files[len(files)-1], os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString(`{"op":"put","record":{"id":50,"message":"` + strings.Repeat("x", 2*1024*1024) + "\"}}\n")
+	_, _ = f.WriteString("not json\n")
+	_ = f.Close()
+
+	s, err = Open(dir, WithSegmentSize(1024))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if s.Skipped() != 2 {
+		t.Errorf("Skipped() = %d, want 2", s.Skipped())
+	}
+	if _, err := s.Append(testMessage(t, "after")); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	_ = s.Close()
+
+	s, err = Open(dir, WithSegmentSize(1024))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer s.Close()
+	pending := s.Pending()
+	if len(pending) != 2 || pending[0].Message != "before" || pending[1].Message != "after" {
+		t.Errorf("Pending() returned %d records, want the records around the skipped lines", len(pending))
+	}
+}
+
+func TestSpool_SizeCap(t *testing.T) {
+	s, err := Open(t.TempDir(), WithSegmentSize(1024), WithMaxBytes(4096))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer s.Close()
+	for i := 0; i < 100; i++ {
+		if _, err := s.Append(testMessage(t, strings.Repeat("x", 100))); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+	if size := s.Size(); size > 4096 {
+		t.Errorf("Size() = %d, want at most 4096", size)
+	}
+	if s.Dropped() == 0 {
+		t.Error("expected the oldest records to be dropped")
+	}
+	if s.Len()+s.Dropped() != 100 {
+		t.Errorf("Len() + Dropped() = %d, want 100", s.Len()+s.Dropped())
+	}
+}
+
+func TestSpool_Retention(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Open(dir, WithRetention(time.Hour))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if _, err := s.Append(testMessage(t, "old")); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	s.expire(time.Now().Add(2 * time.Hour))
+	if s.Len() != 0 || s.Dropped() != 1 {
+		t.Errorf("Len() = %d, Dropped() = %d after retention, want 0 and 1", s.Len(), s.Dropped())
+	}
+	_ = s.Close()
+
+	s, err = Open(dir, WithRetention(0))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer s.Close()
+	if s.Len() != 1 {
+		t.Errorf("expired records are kept on disk until their segment is removed, Len() = %d, want 1", s.Len())
+	}
+}
+
+func TestRecord_MessageContext(t *testing.T) {
+	msg := capture(t, func(m *maleo.Maleo) {
+		_ = m.Wrap(errors.New("connection refused")).
+			Message("failed to query").
+			Code(503).
+			Key("db.query").
+			Context(maleo.F{"query": "SELECT 1"}).
+			Notify(context.Background())
+	})
+
+	b, _ := json.Marshal(NewRecord(msg))
+	var record Record
+	if err := json.Unmarshal(b, &record); err != nil {
+		t.Fatalf("failed to decode record: %v", err)
+	}
+	restored := record.MessageContext(nil)
+	if restored.Message() != "failed to query" || restored.Code() != 503 || restored.Key() != "db.query" {
+		t.Errorf("restored message = %q, code = %d, key = %q", restored.Message(), restored.Code(), restored.Key())
+	}
+	if restored.Err() == nil || restored.Err().Error() != msg.Err().Error() {
+		t.Errorf("restored error = %v, want %v", restored.Err(), msg.Err())
+	}
+	if got, _ := json.Marshal(restored.Err()); !strings.Contains(string(got), "connection refused") {
+		t.Errorf("restored error JSON = %s, want the original error chain", got)
+	}
+	if restored.Caller().String() != msg.Caller().String() {
+		t.Errorf("restored caller = %v, want %v", restored.Caller(), msg.Caller())
+	}
+	if restored.Maleo() != maleo.Global() {
+		t.Error("restored message with nil Maleo must use the Global instance")
+	}
+	got, _ := json.Marshal(restored.Context())
+	if !strings.Contains(string(got), `"query":"SELECT 1"`) {
+		t.Errorf("restored context = %s", got)
+	}
+}
@@ -0,0 +1,116 @@
+package maleo
+
+import (
+	"context"
+	"errors"
+	"os"
+	"time"
+)
+
+// Fatal works like Wrap, but the Error has FatalLevel. Calling .Log(ctx) on it terminates the program. See
+// Maleo.Log for the termination steps.
+func (m *Maleo) Fatal(err error, msgAndArgs ...any) ErrorBuilder {
+	if err == nil {
+		err = ErrNil
+	}
+	caller := GetCaller(m.callerDepth)
+	return m.constructError(&ErrorConstructorContext{
+		Err:            err,
+		Caller:         caller,
+		Maleo:          m,
+		Stack:          m.captureStack(),
+		MessageAndArgs: msgAndArgs,
+	}).Level(FatalLevel)
+}
+
+// Panic works like Wrap, but the Error has PanicLevel. Calling .Log(ctx) on it panics with the Error. See Maleo.Log
+// for the termination steps.
+func (m *Maleo) Panic(err error, msgAndArgs ...any) ErrorBuilder {
+	if err == nil {
+		err = ErrNil
+	}
+	caller := GetCaller(m.callerDepth)
+	return m.constructError(&ErrorConstructorContext{
+		Err:            err,
+		Caller:         caller,
+		Maleo:          m,
+		Stack:          m.captureStack(),
+		MessageAndArgs: msgAndArgs,
+	}).Level(PanicLevel)
+}
+
+// terminates returns true if logging a message with the level must terminate the program.
+func terminates(lvl Level) bool {
+	return lvl >= FatalLevel
+}
+
+var contextKeyNoTermination = contextKey{name: "no-termination"}
+
+// ContextWithoutTermination creates a new context that stops Maleo.Log and Maleo.LogError from terminating the program
+// for messages with FatalLevel or PanicLevel. The messages are still logged with their levels.
+//
+// Used by bridges from other logging APIs, e.g. maleoslog.Handler, whose callers do not expect logging to exit.
+func ContextWithoutTermination(parent context.Context) context.Context {
+	return context.WithValue(parent, contextKeyNoTermination, true)
+}
+
+// terminationDisabled returns true if ctx is created by ContextWithoutTermination.
+func terminationDisabled(ctx context.Context) bool {
+	disabled, _ := ctx.Value(contextKeyNoTermination).(bool)
+	return disabled
+}
+
+// recoveredPanic returns true if the error is created from a recovered panic, e.g. by Recover. Those errors are
+// already handled, so logging them must not terminate the program again.
+func recoveredPanic(err error) bool {
+	var pe *PanicError
+	return errors.As(err, &pe)
+}
+
+/*
+terminate runs the termination steps for messages with FatalLevel or PanicLevel:
+
+ 1. msg is sent to every Messenger, including the benched ones, with ForceSend, regardless of their minimum levels,
+    unless it is dropped by the Hooks of the Engine;
+ 2. the Messengers are waited, up to the terminate timeout, so queued messages are sent;
+ 3. the program exits with code 1 for FatalLevel, or panics with value for PanicLevel.
+*/
+func (m *Maleo) terminate(ctx context.Context, lvl Level, value any, build func(opts *MessageParameters) MessageContext) {
+	opts := m.defaultParams.clone()
+	opts.Messengers = append(opts.Messengers, opts.Benched...)
+	opts.ForceSend = true
+	msg := build(opts)
+
+	ctx = DetachedContext(ctx)
+	messengers := Messengers(opts.Messengers)
+	if msg != nil {
+		messengers.SendMessage(ctx, msg)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.terminateTimeout)
+	_ = messengers.Wait(ctx)
+	cancel()
+
+	if lvl >= PanicLevel {
+		panic(value)
+	}
+	m.exit(1)
+}
+
+func (m *Maleo) terminateEntry(ctx context.Context, entry Entry) {
+	m.terminate(ctx, entry.Level(), entry.Message(), func(opts *MessageParameters) MessageContext {
+		return m.buildEntryMessage(entryWithContextFields(ctx, entry), opts)
+	})
+}
+
+func (m *Maleo) terminateError(ctx context.Context, err Error) {
+	m.terminate(ctx, err.Level(), err, func(opts *MessageParameters) MessageContext {
+		return m.buildErrorMessage(ctx, err, opts)
+	})
+}
+
+const defaultTerminateTimeout = 10 * time.Second
+
+func defaultExit(code int) {
+	os.Exit(code)
+}
@@ -0,0 +1,151 @@
+package maleo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type waitingMessenger struct {
+	recordingMessenger
+	name    string
+	waited  bool
+	timeout bool
+}
+
+func (w *waitingMessenger) Name() string { return w.name }
+
+func (w *waitingMessenger) Wait(ctx context.Context) error {
+	w.waited = true
+	_, w.timeout = ctx.Deadline()
+	return nil
+}
+
+func newTerminatingMaleo(exitCode *int) (*Maleo, *waitingMessenger, *waitingMessenger, *countingLogger) {
+	messenger := &waitingMessenger{name: "messenger"}
+	benched := &waitingMessenger{name: "benched"}
+	logger := &countingLogger{}
+	m := New(Service{}, Option.Init().
+		Logger(logger).
+		Messengers(messenger).
+		MessengerLevel("messenger", PanicLevel+1).
+		ExitFunc(func(code int) { *exitCode = code }).
+		TerminateTimeout(time.Second),
+	)
+	m.RegisterBenched(benched)
+	return m, messenger, benched, logger
+}
+
+func TestMaleo_Fatal(t *testing.T) {
+	exitCode := -1
+	m, messenger, benched, logger := newTerminatingMaleo(&exitCode)
+
+	err := m.Fatal(errors.New("db down"), "failed to start").Log(context.Background())
+	if err.Level() != FatalLevel {
+		t.Errorf("Level() = %v, want %v", err.Level(), FatalLevel)
+	}
+	if logger.count != 1 {
+		t.Errorf("logger count = %d, want 1", logger.count)
+	}
+	if exitCode != 1 {
+		t.Errorf("exit code = %d, want 1", exitCode)
+	}
+	for _, w := range []*waitingMessenger{messenger, benched} {
+		if len(w.messages) != 1 {
+			t.Fatalf("%s received %d messages, want 1", w.name, len(w.messages))
+		}
+		if !w.messages[0].ForceSend() {
+			t.Errorf("%s received message without ForceSend", w.name)
+		}
+		if !w.waited || !w.timeout {
+			t.Errorf("%s waited = %v with timeout = %v, want both true", w.name, w.waited, w.timeout)
+		}
+	}
+}
+
+func TestMaleo_Panic(t *testing.T) {
+	exitCode := -1
+	m, messenger, benched, _ := newTerminatingMaleo(&exitCode)
+
+	var recovered any
+	func() {
+		defer func() { recovered = recover() }()
+		_ = m.Panic(errors.New("invariant broken")).Log(context.Background())
+	}()
+	err, ok := recovered.(Error)
+	if !ok {
+		t.Fatalf("recovered = %v, want Error", recovered)
+	}
+	if err.Level() != PanicLevel {
+		t.Errorf("Level() = %v, want %v", err.Level(), PanicLevel)
+	}
+	if exitCode != -1 {
+		t.Errorf("exit code = %d, want exit not called", exitCode)
+	}
+	if len(messenger.messages) != 1 || len(benched.messages) != 1 {
+		t.Errorf("messengers received %d and %d messages, want 1 each", len(messenger.messages), len(benched.messages))
+	}
+}
+
+func TestMaleo_Log_FatalEntry(t *testing.T) {
+	exitCode := -1
+	m, messenger, _, _ := newTerminatingMaleo(&exitCode)
+
+	m.NewEntry("shutting down").Level(FatalLevel).Log(context.Background())
+	if exitCode != 1 {
+		t.Errorf("exit code = %d, want 1", exitCode)
+	}
+	if len(messenger.messages) != 1 || messenger.messages[0].Message() != "shutting down" {
+		t.Errorf("messenger received %v, want the entry", messenger.messages)
+	}
+}
+
+func TestMaleo_Log_NoTermination(t *testing.T) {
+	exitCode := -1
+	m, messenger, _, logger := newTerminatingMaleo(&exitCode)
+	ctx := context.Background()
+
+	_ = m.Bail("error").Log(ctx)
+	_ = m.Fatal(errors.New("not logged")).Notify(ctx)
+	m.NewEntry("fatal record").Level(FatalLevel).Log(ContextWithoutTermination(ctx))
+	_ = m.Panic(errors.New("panic record")).Log(ContextWithoutTermination(ctx))
+	func() {
+		defer Recover(ContextWithMaleo(ctx, m))
+		panic("recovered")
+	}()
+	if exitCode != -1 {
+		t.Errorf("exit code = %d, want exit not called", exitCode)
+	}
+	if len(messenger.messages) != 0 {
+		t.Errorf("messenger received %d messages, want 0", len(messenger.messages))
+	}
+	if logger.count != 4 {
+		t.Errorf("logger received %d messages, want the error, the recovered panic and both records", logger.count)
+	}
+}
+
+func TestMaleo_Log_DiscardedNoTermination(t *testing.T) {
+	exitCode := -1
+	ctx := context.Background()
+
+	m, messenger, _, logger := newTerminatingMaleo(&exitCode)
+	m.SetEngine(NewEngine(Option.Engine().Hooks(keyDropHook{})))
+	m.NewEntry("dropped").Key("health-check").Level(FatalLevel).Log(ctx)
+	_ = m.Panic(errors.New("dropped")).Key("health-check").Log(ctx)
+
+	filtered := New(Service{}, Option.Init().
+		Logger(logger).
+		Messengers(messenger).
+		LogLevel(PanicLevel+1).
+		ExitFunc(func(code int) { exitCode = code }),
+	)
+	_ = filtered.Fatal(errors.New("filtered")).Log(ctx)
+
+	if exitCode != -1 {
+		t.Errorf("exit code = %d, want exit not called for discarded messages", exitCode)
+	}
+	if len(messenger.messages) != 0 || logger.count != 0 {
+		t.Errorf("messages = %d, logs = %d, want nothing sent or logged", len(messenger.messages), logger.count)
+	}
+}
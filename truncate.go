@@ -0,0 +1,393 @@
+package maleo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Truncator limits the size of the context values of Entries and Errors, so huge or cyclic values do not blow up the
+// Loggers and Messengers.
+//
+// Truncate must not modify the value in place, and should return the value as is if it is within the limits.
+type Truncator interface {
+	Truncate(value any) any
+}
+
+type TruncatorFunc func(value any) any
+
+func (f TruncatorFunc) Truncate(value any) any {
+	return f(value)
+}
+
+// NoopTruncator returns the values as is.
+type NoopTruncator struct{}
+
+func (NoopTruncator) Truncate(value any) any {
+	return value
+}
+
+// TruncatedKey is the key of the marker added to truncated maps.
+const TruncatedKey = "[truncated]"
+
+const cycleMarker = "[cycle]"
+
+func truncatedItems(n int) string {
+	return fmt.Sprintf("[truncated %d items]", n)
+}
+
+func truncatedBytes(n int) string {
+	return fmt.Sprintf("[truncated %d bytes]", n)
+}
+
+type truncator struct {
+	TruncateParameters
+}
+
+/*
+NewTruncator creates a Truncator with the limits set by the options. The default Engine uses NewTruncator() with the
+default limits.
+
+Truncated values leave markers:
+
+  - strings longer than the limit are cut and end with "[truncated N bytes]";
+  - slices and maps with too many elements keep the first elements, with "[truncated N items]" as the last element
+    of slices, or as the value of the "[truncated]" key of maps. Map keys are kept in sorted order;
+  - slices, maps and structs nested deeper than the limit become "[truncated N items]";
+  - values that refer to themselves become "[cycle]";
+  - values whose JSON encoding is longer than the byte limit become the cut JSON string, ending with
+    "[truncated N bytes]".
+*/
+func NewTruncator(opts ...TruncateOption) Truncator {
+	params := defaultTruncateParameters()
+	for _, opt := range opts {
+		opt.Apply(&params)
+	}
+	return &truncator{params}
+}
+
+func (t *truncator) Truncate(value any) any {
+	out, _ := t.walk(value, 0, nil)
+	if t.MaxBytes <= 0 {
+		return out
+	}
+	b, err := json.Marshal(out)
+	if err != nil || len(b) <= t.MaxBytes {
+		return out
+	}
+	cut := b[:t.MaxBytes]
+	for len(cut) > 0 && !utf8.Valid(cut) {
+		cut = cut[:len(cut)-1]
+	}
+	return string(cut) + " " + truncatedBytes(len(b)-len(cut))
+}
+
+func (t *truncator) truncateString(s string) (string, bool) {
+	if t.MaxStringLength <= 0 || len(s) <= t.MaxStringLength {
+		return s, false
+	}
+	cut := s[:t.MaxStringLength]
+	for len(cut) > 0 && !utf8.ValidString(cut) {
+		cut = cut[:len(cut)-1]
+	}
+	return cut + " " + truncatedBytes(len(s)-len(cut)), true
+}
+
+// walk returns the truncated value and whether anything is truncated. seen holds the addresses of the maps, slices and
+// pointers of the parents, to detect cycles.
+func (t *truncator) walk(value any, depth int, seen []uintptr) (any, bool) {
+	switch v := value.(type) {
+	case nil:
+		return nil, false
+	case string:
+		return t.truncateString(v)
+	case json.RawMessage:
+		if out, ok := t.walkJSON(v, depth, seen); ok {
+			return out, true
+		}
+		return v, false
+	case json.Number:
+		return v, false
+	case error:
+		// maleo Errors limit their own context. Other errors are rendered by their messages.
+		return v, false
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.String:
+		if s, ok := t.truncateString(rv.String()); ok {
+			return s, true
+		}
+	case reflect.Map:
+		if rv.IsNil() {
+			return value, false
+		}
+		if isCycle(rv.Pointer(), seen) {
+			return cycleMarker, true
+		}
+		if t.MaxDepth > 0 && depth >= t.MaxDepth {
+			return truncatedItems(rv.Len()), true
+		}
+		return t.walkMap(rv, depth, append(seen, rv.Pointer()))
+	case reflect.Slice:
+		if rv.IsNil() {
+			return value, false
+		}
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			// []byte is encoded as base64 string by encoding/json.
+			if t.MaxStringLength > 0 && rv.Len() > t.MaxStringLength {
+				return truncatedBytes(rv.Len()), true
+			}
+			return value, false
+		}
+		if isCycle(rv.Pointer(), seen) {
+			return cycleMarker, true
+		}
+		if t.MaxDepth > 0 && depth >= t.MaxDepth {
+			return truncatedItems(rv.Len()), true
+		}
+		return t.walkSlice(rv, depth, append(seen, rv.Pointer()))
+	case reflect.Array:
+		if t.MaxDepth > 0 && depth >= t.MaxDepth {
+			return truncatedItems(rv.Len()), true
+		}
+		return t.walkSlice(rv, depth, seen)
+	case reflect.Pointer:
+		if rv.IsNil() {
+			return value, false
+		}
+		if isCycle(rv.Pointer(), seen) {
+			return cycleMarker, true
+		}
+		if _, ok := value.(json.Marshaler); ok {
+			return t.walkMarshaled(value, depth, seen)
+		}
+		if out, ok := t.walk(rv.Elem().Interface(), depth, append(seen, rv.Pointer())); ok {
+			return out, true
+		}
+	case reflect.Struct:
+		if t.MaxDepth > 0 && depth >= t.MaxDepth {
+			return truncatedItems(rv.NumField()), true
+		}
+		return t.walkMarshaled(value, depth, seen)
+	}
+	return value, false
+}
+
+func isCycle(ptr uintptr, seen []uintptr) bool {
+	for _, p := range seen {
+		if p == ptr {
+			return true
+		}
+	}
+	return false
+}
+
+// walkMarshaled limits the values that are not maps or slices, like structs, by their JSON encoding.
+func (t *truncator) walkMarshaled(value any, depth int, seen []uintptr) (any, bool) {
+	b, err := json.Marshal(value)
+	if err != nil {
+		var unsupported *json.UnsupportedValueError
+		if errors.As(err, &unsupported) && strings.Contains(unsupported.Str, "cycle") {
+			return cycleMarker, true
+		}
+		return value, false
+	}
+	if out, ok := t.walkJSON(b, depth, seen); ok {
+		return out, true
+	}
+	return value, false
+}
+
+func (t *truncator) walkJSON(b []byte, depth int, seen []uintptr) (any, bool) {
+	var v any
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return nil, false
+	}
+	return t.walk(v, depth, seen)
+}
+
+func (t *truncator) walkMap(rv reflect.Value, depth int, seen []uintptr) (any, bool) {
+	// Keys that are not strings may collide once formatted, so these maps are kept as they are.
+	if rv.Type().Key().Kind() != reflect.String {
+		return rv.Interface(), false
+	}
+	keys := rv.MapKeys()
+	limited := t.MaxElements > 0 && len(keys) > t.MaxElements
+	if limited {
+		sort.Slice(keys, func(i, j int) bool {
+			return keys[i].String() < keys[j].String()
+		})
+		keys = keys[:t.MaxElements]
+	}
+	values := make(map[string]any, len(keys)+1)
+	changed := limited
+	for _, key := range keys {
+		value := rv.MapIndex(key).Interface()
+		if out, ok := t.walk(value, depth+1, seen); ok {
+			value = out
+			changed = true
+		}
+		values[key.String()] = value
+	}
+	if !changed {
+		return rv.Interface(), false
+	}
+	if limited {
+		values[TruncatedKey] = truncatedItems(rv.Len() - t.MaxElements)
+	}
+	// Keep the type when it can hold the truncated values, e.g. Fields.
+	if rv.Type().Elem().Kind() == reflect.Interface {
+		out := reflect.MakeMapWithSize(rv.Type(), len(values))
+		for k, v := range values {
+			if v == nil {
+				out.SetMapIndex(reflect.ValueOf(k).Convert(rv.Type().Key()), reflect.Zero(rv.Type().Elem()))
+				continue
+			}
+			out.SetMapIndex(reflect.ValueOf(k).Convert(rv.Type().Key()), reflect.ValueOf(v))
+		}
+		return out.Interface(), true
+	}
+	return values, true
+}
+
+func (t *truncator) walkSlice(rv reflect.Value, depth int, seen []uintptr) (any, bool) {
+	n := rv.Len()
+	limited := t.MaxElements > 0 && n > t.MaxElements
+	if limited {
+		n = t.MaxElements
+	}
+	values := make([]any, 0, n+1)
+	changed := limited
+	for i := 0; i < n; i++ {
+		value := rv.Index(i).Interface()
+		if out, ok := t.walk(value, depth+1, seen); ok {
+			value = out
+			changed = true
+		}
+		values = append(values, value)
+	}
+	if !changed {
+		return rv.Interface(), false
+	}
+	if limited {
+		values = append(values, truncatedItems(rv.Len()-t.MaxElements))
+	}
+	return values, true
+}
+
+func (m *Maleo) truncates() bool {
+	if m == nil || m.engine == nil {
+		return false
+	}
+	_, noop := m.truncator().(NoopTruncator)
+	return !noop
+}
+
+// Truncate limits the size of value with the Truncator of the engine.
+//
+// Entries and Errors limit their context values already. Use this for data that is encoded by other means.
+func (m *Maleo) Truncate(value any) any {
+	return m.truncator().Truncate(value)
+}
+
+// truncateContext limits the context values of Entries and Errors. Multiple values are key-value pairs, which are
+// limited together as a map, so the byte limit applies to the whole context.
+func (m *Maleo) truncateContext(ctx []any) []any {
+	if !m.truncates() || len(ctx) == 0 {
+		return ctx
+	}
+	if len(ctx) == 1 {
+		return []any{m.Truncate(ctx[0])}
+	}
+	pairs := make(F, len(ctx)/2)
+	keys := make([]string, 0, len(ctx)/2)
+	for i := 0; i+1 < len(ctx); i += 2 {
+		key, ok := ctx[i].(string)
+		if !ok {
+			key = fmt.Sprint(ctx[i])
+		}
+		if _, exists := pairs[key]; !exists {
+			keys = append(keys, key)
+		}
+		pairs[key] = ctx[i+1]
+	}
+	truncated := m.Truncate(pairs)
+	out, ok := truncated.(F)
+	if !ok {
+		return []any{truncated}
+	}
+	res := make([]any, 0, len(out)*2)
+	for _, key := range keys {
+		if v, ok := out[key]; ok {
+			res = append(res, key, v)
+		}
+	}
+	if marker, ok := out[TruncatedKey]; ok {
+		res = append(res, TruncatedKey, marker)
+	}
+	return res
+}
+
+func (m *Maleo) truncateFields(fields Fields) Fields {
+	if !m.truncates() || len(fields) == 0 {
+		return fields
+	}
+	switch out := m.Truncate(fields).(type) {
+	case Fields:
+		return out
+	case string:
+		return Fields{TruncatedKey: out}
+	}
+	return fields
+}
+
+// contextValues returns the context values of Entries and Errors, redacted and limited by the engine.
+func (m *Maleo) contextValues(ctx []any) []any {
+	return m.truncateContext(m.redactContext(ctx))
+}
+
+// contextFields returns the fields of Entries and Errors, redacted and limited by the engine.
+func (m *Maleo) contextFields(fields Fields) Fields {
+	return m.truncateFields(m.redactFields(fields))
+}
+
+// cachedValues holds the context values of a node once they are redacted and limited, so the Redactor and Truncator
+// run once per node instead of on every call of Context.
+type cachedValues struct {
+	once   sync.Once
+	values []any
+}
+
+// get returns the values computed by the first call. A nil cache computes the values on every call.
+func (c *cachedValues) get(compute func() []any) []any {
+	if c == nil {
+		return compute()
+	}
+	c.once.Do(func() { c.values = compute() })
+	return c.values
+}
+
+// cachedFields holds the fields of a node once they are redacted and limited, so the Redactor and Truncator run once
+// per node instead of on every call of ContextFields.
+type cachedFields struct {
+	once   sync.Once
+	fields Fields
+}
+
+// get returns the fields computed by the first call. A nil cache computes the fields on every call.
+func (c *cachedFields) get(compute func() Fields) Fields {
+	if c == nil {
+		return compute()
+	}
+	c.once.Do(func() { c.fields = compute() })
+	return c.fields
+}
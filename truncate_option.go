@@ -0,0 +1,68 @@
+package maleo
+
+type TruncateParameters struct {
+	// MaxStringLength is the maximum length of strings, in bytes. Defaults to 8192.
+	MaxStringLength int
+	// MaxElements is the maximum number of elements of slices and maps. Defaults to 256.
+	MaxElements int
+	// MaxDepth is the maximum nesting depth of slices, maps and structs. Defaults to 16.
+	MaxDepth int
+	// MaxBytes is the maximum length of the JSON encoding of a value, in bytes. Defaults to 1 MiB.
+	MaxBytes int
+}
+
+func defaultTruncateParameters() TruncateParameters {
+	return TruncateParameters{
+		MaxStringLength: 8192,
+		MaxElements:     256,
+		MaxDepth:        16,
+		MaxBytes:        1 << 20,
+	}
+}
+
+type TruncateOption interface {
+	Apply(*TruncateParameters)
+}
+
+type (
+	TruncateOptionBuilder []TruncateOption
+	TruncateOptionFunc    func(*TruncateParameters)
+)
+
+func (t TruncateOptionFunc) Apply(parameters *TruncateParameters) {
+	t(parameters)
+}
+
+func (t TruncateOptionBuilder) Apply(parameters *TruncateParameters) {
+	for _, opt := range t {
+		opt.Apply(parameters)
+	}
+}
+
+// MaxStringLength sets the maximum length of strings, in bytes. Set to 0 to not limit.
+func (t TruncateOptionBuilder) MaxStringLength(n int) TruncateOptionBuilder {
+	return append(t, TruncateOptionFunc(func(p *TruncateParameters) {
+		p.MaxStringLength = n
+	}))
+}
+
+// MaxElements sets the maximum number of elements of slices and maps. Set to 0 to not limit.
+func (t TruncateOptionBuilder) MaxElements(n int) TruncateOptionBuilder {
+	return append(t, TruncateOptionFunc(func(p *TruncateParameters) {
+		p.MaxElements = n
+	}))
+}
+
+// MaxDepth sets the maximum nesting depth of slices, maps and structs. Set to 0 to not limit.
+func (t TruncateOptionBuilder) MaxDepth(n int) TruncateOptionBuilder {
+	return append(t, TruncateOptionFunc(func(p *TruncateParameters) {
+		p.MaxDepth = n
+	}))
+}
+
+// MaxBytes sets the maximum length of the JSON encoding of a value, in bytes. Set to 0 to not limit.
+func (t TruncateOptionBuilder) MaxBytes(n int) TruncateOptionBuilder {
+	return append(t, TruncateOptionFunc(func(p *TruncateParameters) {
+		p.MaxBytes = n
+	}))
+}
@@ -0,0 +1,120 @@
+package maleo
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTruncator_Truncate(t *testing.T) {
+	truncator := NewTruncator(Option.Truncate().MaxStringLength(5).MaxElements(2).MaxDepth(2).MaxBytes(0))
+
+	type node struct {
+		Name string `json:"name"`
+	}
+
+	tests := []struct {
+		name  string
+		value any
+		want  any
+	}{
+		{
+			name:  "string",
+			value: "abcdefgh",
+			want:  "abcde [truncated 3 bytes]",
+		},
+		{
+			name:  "slice elements",
+			value: []int{1, 2, 3, 4},
+			want:  []any{1, 2, "[truncated 2 items]"},
+		},
+		{
+			name:  "map elements",
+			value: F{"c": 3, "a": 1, "b": 2},
+			want:  F{"a": 1, "b": 2, TruncatedKey: "[truncated 1 items]"},
+		},
+		{
+			name:  "depth",
+			value: F{"a": F{"b": F{"c": 1}}},
+			want:  F{"a": F{"b": "[truncated 1 items]"}},
+		},
+		{
+			name:  "struct",
+			value: F{"node": node{Name: "abcdefgh"}},
+			want:  F{"node": map[string]any{"name": "abcde [truncated 3 bytes]"}},
+		},
+		{
+			name:  "non-string keys are kept",
+			value: map[int]string{1: "abcdefgh", 2: "b", 3: "c"},
+			want:  map[int]string{1: "abcdefgh", 2: "b", 3: "c"},
+		},
+		{
+			name:  "untouched value",
+			value: F{"a": 1, "b": "abc"},
+			want:  F{"a": 1, "b": "abc"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := truncator.Truncate(tt.value); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Truncate() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTruncator_Cycle(t *testing.T) {
+	type node struct {
+		Name string `json:"name"`
+		Next *node  `json:"next"`
+	}
+	truncator := NewTruncator()
+
+	m := F{"name": "root"}
+	m["self"] = m
+	if got, want := truncator.Truncate(m), (F{"name": "root", "self": "[cycle]"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Truncate() = %#v, want %#v", got, want)
+	}
+
+	n := &node{Name: "root"}
+	n.Next = n
+	if got := truncator.Truncate(F{"node": n}); !reflect.DeepEqual(got, F{"node": "[cycle]"}) {
+		t.Errorf("Truncate() = %#v, want the cycle marker", got)
+	}
+}
+
+func TestTruncator_MaxBytes(t *testing.T) {
+	truncator := NewTruncator(Option.Truncate().MaxBytes(16))
+	got, ok := truncator.Truncate(F{"message": strings.Repeat("a", 32)}).(string)
+	if !ok {
+		t.Fatalf("Truncate() = %#v, want string", got)
+	}
+	if want := `{"message":"aaaa [truncated 30 bytes]`; got != want {
+		t.Errorf("Truncate() = %q, want %q", got, want)
+	}
+}
+
+func TestMaleo_Truncate(t *testing.T) {
+	m, logger := NewTestingMaleo()
+	m.SetEngine(NewEngine(Option.Engine().Truncator(NewTruncator(Option.Truncate().MaxElements(2)))))
+	messenger := &recordingMessenger{}
+	m.Register(messenger)
+	ctx := context.Background()
+
+	_ = m.Bail("too much").
+		Context("ids", []int{1, 2, 3}, "name", "kilua").
+		Log(ctx).
+		Notify(ctx)
+
+	if out := logger.String(); !strings.Contains(out, "[truncated 1 items]") {
+		t.Errorf("log misses the truncation marker: %s", out)
+	}
+	if len(messenger.messages) != 1 {
+		t.Fatalf("messenger received %d messages, want 1", len(messenger.messages))
+	}
+	want := []any{"ids", []any{1, 2, "[truncated 1 items]"}, "name", "kilua"}
+	if got := messenger.messages[0].Context(); !reflect.DeepEqual(got, want) {
+		t.Errorf("message context = %#v, want %#v", got, want)
+	}
+}